@@ -33,6 +33,15 @@ type PostHandler interface {
 	Post(post *reddit.Post) error
 }
 
+// PostFlairHandler defines methods for bots that handle changes to the flair of
+// posts in subreddits they monitor.
+type PostFlairHandler interface {
+	// PostFlair is called when the flair of a post in a monitored
+	// subreddit changes. The post carries its new flair. [Called as
+	// goroutine.]
+	PostFlair(post *reddit.Post) error
+}
+
 // CommentHandler defines methods for bots that handle new comments in
 // subreddits they monitor.
 type CommentHandler interface {
@@ -104,3 +113,31 @@ type UserHandler interface {
 	// subreddit the bot can view. [Called as goroutine.]
 	UserComment(comment *reddit.Comment) error
 }
+
+// ModQueueHandler defines methods for bots that handle posts and comments in
+// the moderation listings of subreddits they moderate. The listing is named
+// "modqueue", "reports", "spam" or "unmoderated".
+type ModQueueHandler interface {
+	// ModQueuePost is called when a post appears in a monitored moderation
+	// listing. [Called as goroutine.]
+	ModQueuePost(listing string, post *reddit.Post) error
+	// ModQueueComment is called when a comment appears in a monitored
+	// moderation listing. [Called as goroutine.]
+	ModQueueComment(listing string, comment *reddit.Comment) error
+}
+
+// ModmailHandler defines methods for bots that handle new messages in the
+// modmail of subreddits they moderate.
+type ModmailHandler interface {
+	// Modmail is called when a message is posted in a modmail
+	// conversation of a monitored subreddit. [Called as goroutine.]
+	Modmail(msg *reddit.ModmailMessage) error
+}
+
+// ModActionHandler defines methods for bots that handle entries in the
+// moderation logs of subreddits they moderate.
+type ModActionHandler interface {
+	// ModAction is called when a moderator takes an action in a
+	// monitored subreddit. [Called as goroutine.]
+	ModAction(action *reddit.ModAction) error
+}
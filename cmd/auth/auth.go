@@ -0,0 +1,196 @@
+// Package auth is a utility to authorize a Reddit app to use an account and
+// write an agent file with the granted refresh token, which the reddit package
+// can load with NewBotFromAgentFile.
+//
+// Register your app at https://www.reddit.com/prefs/apps with the redirect uri
+// given to this tool (http://localhost:8080/authorize_callback by default), run
+// it, and open the printed address in a browser logged in to the account.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/turnage/graw/reddit"
+	"golang.org/x/oauth2"
+)
+
+var (
+	app      = kingpin.New("auth", "A cli tool for authorizing a Reddit app to use an account.")
+	id       = app.Flag("id", "Client id of the app.").Required().String()
+	secret   = app.Flag("secret", "Client secret of the app; installed apps have none.").String()
+	agent    = app.Flag("user-agent", "User agent the bot will use.").Required().String()
+	redirect = app.Flag("redirect", "Redirect uri registered for the app.").Default("http://localhost:8080/authorize_callback").String()
+	scopes   = app.Flag("scope", "OAuth2 scope to request; repeat for each scope.").Default(reddit.DefaultScopes...).Strings()
+	timeout  = app.Flag("timeout", "How long to wait for the authorization.").Default("5m").Duration()
+	out      = app.Arg("agentfile", "Filename of the agent file to write.").Required().String()
+)
+
+// authorize announces the address of Reddit's authorization page for the app,
+// serves its redirect uri on the listener until Reddit redirects the user
+// there with an authorization code, and exchanges the code for a token.
+func authorize(
+	a reddit.App,
+	agent string,
+	ln net.Listener,
+	wait time.Duration,
+	announce func(authURL string),
+) (*oauth2.Token, error) {
+	redirect, err := url.Parse(a.RedirectURL)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirect.Path, callback(state, codes, errs))
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(ln)
+	defer server.Close()
+
+	announce(a.AuthCodeURL(state))
+
+	var code string
+	select {
+	case code = <-codes:
+	case err := <-errs:
+		return nil, err
+	case <-time.After(wait):
+		return nil, fmt.Errorf("timed out waiting for authorization")
+	}
+
+	token, err := a.Exchange(context.Background(), agent, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RefreshToken == "" {
+		return nil, fmt.Errorf("Reddit did not grant a refresh token")
+	}
+
+	return token, nil
+}
+
+// callback handles Reddit's redirects after the user grants or refuses the
+// authorization, sending the code or error of the first redirect with the
+// given state. Later redirects, such as from a reloaded page, are not taken
+// and do not block.
+func callback(
+	state string,
+	codes chan<- string,
+	errs chan<- error,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Get("state") != state:
+			http.Error(w, "Unexpected state.", http.StatusBadRequest)
+			return
+		case q.Get("error") != "":
+			select {
+			case errs <- fmt.Errorf("authorization failed: %s", q.Get("error")):
+			default:
+			}
+		default:
+			select {
+			case codes <- q.Get("code"):
+			default:
+			}
+		}
+		fmt.Fprintln(w, "Done; you may close this window.")
+	}
+}
+
+// writeAgentFile writes an agent file for the app with the token's refresh
+// token.
+func writeAgentFile(
+	w io.Writer,
+	a reddit.App,
+	agent string,
+	token *oauth2.Token,
+) error {
+	_, err := fmt.Fprintf(
+		w,
+		"user_agent: %s\nclient_id: %s\nclient_secret: %s\nrefresh_token: %s\n",
+		strconv.Quote(agent),
+		strconv.Quote(a.ID),
+		strconv.Quote(a.Secret),
+		strconv.Quote(token.RefreshToken),
+	)
+	if err != nil || a.TokenURL == "" {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "token_url: %s\n", strconv.Quote(a.TokenURL))
+	return err
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func main() {
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	a := reddit.App{
+		ID:          *id,
+		Secret:      *secret,
+		RedirectURL: *redirect,
+		Scopes:      *scopes,
+	}
+
+	u, err := url.Parse(a.RedirectURL)
+	if err != nil {
+		log.Fatalf("Invalid redirect uri: %v\n", err)
+	}
+
+	ln, err := net.Listen("tcp", u.Host)
+	if err != nil {
+		log.Fatalf("Failed to listen for the redirect: %v\n", err)
+	}
+
+	token, err := authorize(a, *agent, ln, *timeout, func(authURL string) {
+		fmt.Printf("Open this address in a browser logged in to the bot's account:\n\n%s\n\n", authURL)
+	})
+	if err != nil {
+		log.Fatalf("Failed to authorize: %v\n", err)
+	}
+
+	f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		log.Fatalf("Failed to create agent file: %v\n", err)
+	}
+
+	if err := writeAgentFile(f, a, *agent, token); err != nil {
+		f.Close()
+		log.Fatalf("Failed to write agent file: %v\n", err)
+	}
+
+	if err := f.Close(); err != nil {
+		log.Fatalf("Failed to write agent file: %v\n", err)
+	}
+
+	fmt.Printf("Wrote %s.\n", *out)
+}
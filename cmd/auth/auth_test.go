@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/turnage/graw/reddit"
+)
+
+// tokenServer serves an OAuth2 token endpoint which grants the refresh token
+// "refresh" for the authorization code "code", and access tokens for that
+// refresh token.
+func tokenServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "id" || secret != "secret" {
+			t.Errorf("token request missing client credentials")
+		}
+
+		if agent := r.Header.Get("User-Agent"); agent != "agent" {
+			t.Errorf("token request has user agent %q; wanted agent", agent)
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse token request: %v", err)
+		}
+
+		switch {
+		case r.PostForm.Get("grant_type") == "authorization_code" &&
+			r.PostForm.Get("code") == "code":
+		case r.PostForm.Get("grant_type") == "refresh_token" &&
+			r.PostForm.Get("refresh_token") == "refresh":
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(
+			w,
+			`{"access_token": "access", "refresh_token": "refresh", "token_type": "bearer", "expires_in": 3600}`,
+		)
+	}))
+}
+
+func TestAuthorize(t *testing.T) {
+	serv := tokenServer(t)
+	defer serv.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen for the redirect: %v", err)
+	}
+
+	a := reddit.App{
+		ID:          "id",
+		Secret:      "secret",
+		RedirectURL: "http://" + ln.Addr().String() + "/callback",
+		TokenURL:    serv.URL,
+	}
+
+	// The announced address is followed as Reddit would after the grant,
+	// by redirecting to the redirect uri with a code.
+	redirects := make(chan error, 1)
+	token, err := authorize(a, "agent", ln, 5*time.Second, func(authURL string) {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			t.Errorf("failed to parse authorization address: %v", err)
+			return
+		}
+
+		if q := u.Query(); q.Get("duration") != "permanent" ||
+			q.Get("client_id") != "id" {
+			t.Errorf("authorization address incorrect: %s", authURL)
+		}
+
+		go func() {
+			resp, err := http.Get(a.RedirectURL + "?" + url.Values{
+				"state": {u.Query().Get("state")},
+				"code":  {"code"},
+			}.Encode())
+			if err == nil {
+				resp.Body.Close()
+			}
+			redirects <- err
+		}()
+	})
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+
+	if err := <-redirects; err != nil {
+		t.Errorf("redirect failed: %v", err)
+	}
+
+	if token.RefreshToken != "refresh" {
+		t.Errorf("got refresh token %q; wanted refresh", token.RefreshToken)
+	}
+
+	dir, err := ioutil.TempDir("", "agents")
+	if err != nil {
+		t.Fatalf("failed to make test directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	if err := writeAgentFile(&buf, a, "agent", token); err != nil {
+		t.Fatalf("failed to write agent file: %v", err)
+	}
+
+	for i, name := range []string{"agent", "agent.yaml"} {
+		filename := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(filename, buf.Bytes(), 0600); err != nil {
+			t.Fatalf("failed to write test input file: %v", err)
+		}
+
+		if _, err := reddit.NewBotFromAgentFile(filename, 0); err != nil {
+			t.Errorf("%d: failed to load the agent file: %v", i, err)
+		}
+	}
+}
+
+func TestAuthorizeBadState(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen for the redirect: %v", err)
+	}
+
+	a := reddit.App{
+		ID:          "id",
+		RedirectURL: "http://" + ln.Addr().String() + "/callback",
+	}
+
+	codes := make(chan int, 1)
+	_, err = authorize(a, "agent", ln, time.Second, func(string) {
+		go func() {
+			resp, err := http.Get(a.RedirectURL + "?state=forged&code=code")
+			if err != nil {
+				codes <- 0
+				return
+			}
+			resp.Body.Close()
+			codes <- resp.StatusCode
+		}()
+	})
+	if err == nil {
+		t.Errorf("wanted authorization to time out")
+	}
+
+	if code := <-codes; code != http.StatusBadRequest {
+		t.Errorf("wanted forged state rejected; got status %d", code)
+	}
+}
+
+func TestCallbackTakesFirst(t *testing.T) {
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+	h := callback("state", codes, errs)
+
+	// Nothing reads the callbacks, as when the page is reloaded after the
+	// first was taken; none of them may block.
+	for i, query := range []string{
+		"state=state&code=first",
+		"state=state&code=second",
+		"state=state&error=access_denied",
+		"state=state&error=access_denied",
+	} {
+		done := make(chan bool)
+		go func() {
+			h(httptest.NewRecorder(), httptest.NewRequest("GET", "/callback?"+query, nil))
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("%d: callback blocked", i)
+		}
+	}
+
+	if code := <-codes; code != "first" {
+		t.Errorf("got code %q; wanted first", code)
+	}
+}
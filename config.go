@@ -1,7 +1,11 @@
 package graw
 
 import (
+	"context"
 	"log"
+
+	"github.com/turnage/graw/streams"
+	"github.com/turnage/graw/streams/checkpoint"
 )
 
 // Config configures a graw run or scan by specifying event sources. Each event
@@ -15,6 +19,16 @@ type Config struct {
 	// PostHandler.
 	// Key is username, value is list of feeds
 	CustomFeeds map[string][]string
+	// Changes to the flair of the 100 newest posts in all subreddits named
+	// here, by their authors or moderators, will be forwarded to the bot's
+	// PostFlairHandler.
+	PostFlair []string
+	// Posts whose flair moderators change in all subreddits named here
+	// will be forwarded to the bot's PostFlairHandler. The changes are
+	// read from the moderation log, so they are not missed while the bot
+	// is down, but the bot's account must moderate these subreddits and
+	// be granted the "modlog" scope.
+	FlairEdits []string
 	// New comments in all subreddits named here will be forwarded to the
 	// bot's CommentHandler.
 	SubredditComments []string
@@ -35,7 +49,50 @@ type Config struct {
 	// When true, messages sent to the bot's inbox will be forwarded to the
 	// bot's MessageHandler.
 	Messages bool
+	// Posts and comments in the moderation queue, reports, spam, or
+	// unmoderated listings of all subreddits named here will be forwarded
+	// to the bot's ModQueueHandler. The bot's account must moderate these
+	// subreddits; name "mod" for all subreddits it moderates.
+	ModQueue    []string
+	Reports     []string
+	Spam        []string
+	Unmoderated []string
+	// Actions moderators take in all subreddits named here will be
+	// forwarded to the bot's ModActionHandler. The bot's account must
+	// moderate these subreddits and be granted the "modlog" scope.
+	ModLog []string
+	// New modmail messages in the conversations of all subreddits named
+	// here will be forwarded to the bot's ModmailHandler. The bot's
+	// account must moderate these subreddits and be granted the "modmail"
+	// scope; name "mod" for all subreddits it moderates.
+	Modmail []string
+	// If set, the position of every event source is saved here as events
+	// are processed, and event sources resume from their saved positions
+	// when the bot is restarted, delivering events that happened while it
+	// was down. Use checkpoint.NewFile for a file backed store.
+	Checkpoints checkpoint.Store
+	// CatchUp is the maximum number of events per event source that
+	// happened while the bot was down that will be delivered when
+	// resuming from Checkpoints. If not positive, a default of 100 is
+	// used.
+	CatchUp int
 	// If set, internal messages will be logged here. This is a spammy log
 	// used for debugging graw.
 	Logger *log.Logger
 }
+
+// streamer returns a Streamer which provisions event sources as configured,
+// whose requests are made in the given context.
+func (c Config) streamer(ctx context.Context) *streams.Streamer {
+	return &streams.Streamer{
+		Checkpoints: c.Checkpoints,
+		CatchUp:     c.CatchUp,
+		Context:     ctx,
+	}
+}
+
+// modQueues is true if any moderation listings are requested.
+func (c Config) modQueues() bool {
+	return len(c.ModQueue) > 0 || len(c.Reports) > 0 ||
+		len(c.Spam) > 0 || len(c.Unmoderated) > 0
+}
@@ -1,6 +1,8 @@
 package graw
 
 import (
+	"context"
+	"errors"
 	"log"
 
 	"github.com/turnage/graw/botfaces"
@@ -49,6 +51,21 @@ func launch(
 	return stop, wait, nil
 }
 
+// killContext returns a context for the requests of a run, which is cancelled
+// once kill is closed. The returned cancel function cancels it early, for runs
+// which fail to start.
+func killContext(kill <-chan bool) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-kill:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+	return ctx, cancel
+}
+
 func foreman(
 	kill <-chan bool,
 	killChildren chan<- bool,
@@ -61,13 +78,13 @@ func foreman(
 		case <-kill:
 			return nil
 		case err := <-errs:
-			switch err {
-			case nil:
-			case reddit.BusyErr:
+			switch {
+			case err == nil:
+			case errors.Is(err, reddit.BusyErr):
 				logger.Printf("Reddit was busy; staying up.")
-			case reddit.GatewayErr:
+			case errors.Is(err, reddit.GatewayErr):
 				logger.Printf("Bad gateway error; staying up.")
-			case reddit.GatewayTimeoutErr:
+			case errors.Is(err, reddit.GatewayTimeoutErr):
 				logger.Printf("Gateway timeout; staying up.")
 			default:
 				return err
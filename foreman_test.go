@@ -57,6 +57,7 @@ func TestForemanError(t *testing.T) {
 		errs <- reddit.BusyErr
 		errs <- reddit.GatewayErr
 		errs <- reddit.GatewayTimeoutErr
+		errs <- &reddit.APIError{StatusCode: 503}
 		errs <- uniqueError
 	}()
 	waitForForeman(result, uniqueError, t)
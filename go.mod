@@ -10,4 +10,5 @@ require (
 	github.com/turnage/redditproto v0.0.0-20151223012412-afedf1b6eddb
 	golang.org/x/net v0.7.0
 	golang.org/x/oauth2 v0.0.0-20191202225959-858c2ad4c8b6
+	gopkg.in/yaml.v3 v3.0.1
 )
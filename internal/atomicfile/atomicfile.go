@@ -0,0 +1,35 @@
+// Package atomicfile replaces files atomically, so that readers and crashes
+// never observe a partly written file.
+package atomicfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Write replaces the contents of the named file with buf, creating the file if
+// it does not exist. The contents are written to a temporary file in the same
+// directory, which is renamed over the file. New files are only readable by
+// their owner.
+func Write(filename string, buf []byte) error {
+	tmp, err := ioutil.TempFile(
+		filepath.Dir(filename),
+		filepath.Base(filename)+".tmp",
+	)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,37 @@
+package atomicfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "atomicfile")
+	if err != nil {
+		t.Fatalf("failed to make test directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "file")
+	for _, contents := range []string{"first", "second"} {
+		if err := Write(filename, []byte(contents)); err != nil {
+			t.Fatalf("failed to write %q: %v", contents, err)
+		}
+
+		buf, err := ioutil.ReadFile(filename)
+		if err != nil || string(buf) != contents {
+			t.Errorf("got %q, %v; wanted %q", buf, err, contents)
+		}
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("wanted only the file left in the directory; got %v, %v", entries, err)
+	}
+
+	if mode := entries[0].Mode().Perm(); mode != 0600 {
+		t.Errorf("wanted a file only its owner can read; got mode %v", mode)
+	}
+}
@@ -1,6 +1,13 @@
 package reddit
 
+import (
+	"context"
+)
+
 // Account defines behaviors only an account can perform on Reddit.
+//
+// Every method has a Context variant which binds its request, including the
+// wait for the rate limit, to the given context.
 type Account interface {
 	// Reply posts a reply to something on reddit. The behavior depends on
 	// what is being replied to. For
@@ -12,20 +19,83 @@ type Account interface {
 	// Use .Name on the parent post, message, or comment to find its
 	// name.
 	Reply(parentName, text string) error
+	ReplyContext(ctx context.Context, parentName, text string) error
 	GetReply(parentName, text string) (Submission, error)
+	GetReplyContext(ctx context.Context, parentName, text string) (Submission, error)
 
 	// SendMessage sends a private message to a user.
 	SendMessage(user, subject, text string) error
+	SendMessageContext(ctx context.Context, user, subject, text string) error
 
 	// PostSelf makes a text (self) post to a subreddit.
 	PostSelf(subreddit, title, text string) error
+	PostSelfContext(ctx context.Context, subreddit, title, text string) error
 	GetPostSelf(subreddit, title, text string) (Submission, error)
+	GetPostSelfContext(ctx context.Context, subreddit, title, text string) (Submission, error)
 
 	// PostLink makes a link post to a subreddit.
 	PostLink(subreddit, title, url string) error
+	PostLinkContext(ctx context.Context, subreddit, title, url string) error
 	GetPostLink(subreddit, title, url string) (Submission, error)
+	GetPostLinkContext(ctx context.Context, subreddit, title, url string) (Submission, error)
+
+	// Upvote, Downvote and ClearVote vote on a post or comment, given its
+	// name. Reddit refuses votes on archived items with an *APIError with
+	// Code "TOO_OLD".
+	Upvote(name string) error
+	UpvoteContext(ctx context.Context, name string) error
+	Downvote(name string) error
+	DownvoteContext(ctx context.Context, name string) error
+	ClearVote(name string) error
+	ClearVoteContext(ctx context.Context, name string) error
+
+	// Edit replaces the text of one of the account's comments or self
+	// posts, given its name, and returns the edited item.
+	Edit(name, text string) (Submission, error)
+	EditContext(ctx context.Context, name, text string) (Submission, error)
+
+	// Delete deletes one of the account's posts or comments, given its
+	// name.
+	Delete(name string) error
+	DeleteContext(ctx context.Context, name string) error
+
+	// LinkFlairTemplates and UserFlairTemplates list the flairs a
+	// subreddit offers for posts and users. They require the "flair"
+	// OAuth2 scope.
+	LinkFlairTemplates(subreddit string) ([]*FlairTemplate, error)
+	LinkFlairTemplatesContext(ctx context.Context, subreddit string) ([]*FlairTemplate, error)
+	UserFlairTemplates(subreddit string) ([]*FlairTemplate, error)
+	UserFlairTemplatesContext(ctx context.Context, subreddit string) ([]*FlairTemplate, error)
+
+	// SelectFlair sets the flair of a post, given its name, to one of its
+	// subreddit's flair templates by id. If text is not empty and the
+	// template's text is editable, it replaces the template's text. It
+	// requires the "flair" OAuth2 scope; accounts may flair their own
+	// posts, moderators may flair any post in their subreddits.
+	SelectFlair(name, templateID, text string) error
+	SelectFlairContext(ctx context.Context, name, templateID, text string) error
+
+	// SetUserFlair sets the flair of a user in a subreddit to one of the
+	// subreddit's user flair templates by id, replacing the template's
+	// text with text if it is not empty and the template allows it. It
+	// requires the "flair" OAuth2 scope; accounts may set their own flair
+	// if the subreddit allows it, moderators may set anyone's.
+	SetUserFlair(subreddit, user, templateID, text string) error
+	SetUserFlairContext(ctx context.Context, subreddit, user, templateID, text string) error
+
+	// Me returns the account itself. It requires the "identity" OAuth2
+	// scope.
+	Me() (*Redditor, error)
+	MeContext(ctx context.Context) (*Redditor, error)
 }
 
+// Vote directions understood by /api/vote.
+const (
+	upvote    = "1"
+	downvote  = "-1"
+	clearVote = "0"
+)
+
 type account struct {
 	// r is used to execute requests to Reddit.
 	r reaper
@@ -40,8 +110,12 @@ func newAccount(r reaper) Account {
 }
 
 func (a *account) Reply(parentName, text string) error {
+	return a.ReplyContext(context.Background(), parentName, text)
+}
+
+func (a *account) ReplyContext(ctx context.Context, parentName, text string) error {
 	return a.r.sow(
-		"/api/comment", map[string]string{
+		ctx, "/api/comment", map[string]string{
 			"thing_id": parentName,
 			"text":     text,
 		},
@@ -49,8 +123,15 @@ func (a *account) Reply(parentName, text string) error {
 }
 
 func (a *account) GetReply(parentName, text string) (Submission, error) {
+	return a.GetReplyContext(context.Background(), parentName, text)
+}
+
+func (a *account) GetReplyContext(
+	ctx context.Context,
+	parentName, text string,
+) (Submission, error) {
 	return a.r.get_sow(
-		"/api/comment", map[string]string{
+		ctx, "/api/comment", map[string]string{
 			"thing_id": parentName,
 			"text":     text,
 		},
@@ -58,8 +139,15 @@ func (a *account) GetReply(parentName, text string) (Submission, error) {
 }
 
 func (a *account) SendMessage(user, subject, text string) error {
+	return a.SendMessageContext(context.Background(), user, subject, text)
+}
+
+func (a *account) SendMessageContext(
+	ctx context.Context,
+	user, subject, text string,
+) error {
 	return a.r.sow(
-		"/api/compose", map[string]string{
+		ctx, "/api/compose", map[string]string{
 			"to":      user,
 			"subject": subject,
 			"text":    text,
@@ -68,8 +156,15 @@ func (a *account) SendMessage(user, subject, text string) error {
 }
 
 func (a *account) PostSelf(subreddit, title, text string) error {
+	return a.PostSelfContext(context.Background(), subreddit, title, text)
+}
+
+func (a *account) PostSelfContext(
+	ctx context.Context,
+	subreddit, title, text string,
+) error {
 	return a.r.sow(
-		"/api/submit", map[string]string{
+		ctx, "/api/submit", map[string]string{
 			"sr":    subreddit,
 			"kind":  "self",
 			"title": title,
@@ -79,8 +174,15 @@ func (a *account) PostSelf(subreddit, title, text string) error {
 }
 
 func (a *account) GetPostSelf(subreddit, title, text string) (Submission, error) {
+	return a.GetPostSelfContext(context.Background(), subreddit, title, text)
+}
+
+func (a *account) GetPostSelfContext(
+	ctx context.Context,
+	subreddit, title, text string,
+) (Submission, error) {
 	return a.r.get_sow(
-		"/api/submit", map[string]string{
+		ctx, "/api/submit", map[string]string{
 			"sr":    subreddit,
 			"kind":  "self",
 			"title": title,
@@ -90,8 +192,15 @@ func (a *account) GetPostSelf(subreddit, title, text string) (Submission, error)
 }
 
 func (a *account) PostLink(subreddit, title, url string) error {
+	return a.PostLinkContext(context.Background(), subreddit, title, url)
+}
+
+func (a *account) PostLinkContext(
+	ctx context.Context,
+	subreddit, title, url string,
+) error {
 	return a.r.sow(
-		"/api/submit", map[string]string{
+		ctx, "/api/submit", map[string]string{
 			"sr":    subreddit,
 			"kind":  "link",
 			"title": title,
@@ -101,8 +210,15 @@ func (a *account) PostLink(subreddit, title, url string) error {
 }
 
 func (a *account) GetPostLink(subreddit, title, url string) (Submission, error) {
+	return a.GetPostLinkContext(context.Background(), subreddit, title, url)
+}
+
+func (a *account) GetPostLinkContext(
+	ctx context.Context,
+	subreddit, title, url string,
+) (Submission, error) {
 	return a.r.get_sow(
-		"/api/submit", map[string]string{
+		ctx, "/api/submit", map[string]string{
 			"sr":    subreddit,
 			"kind":  "link",
 			"title": title,
@@ -110,3 +226,154 @@ func (a *account) GetPostLink(subreddit, title, url string) (Submission, error)
 		},
 	)
 }
+
+func (a *account) Upvote(name string) error {
+	return a.UpvoteContext(context.Background(), name)
+}
+
+func (a *account) UpvoteContext(ctx context.Context, name string) error {
+	return a.vote(ctx, name, upvote)
+}
+
+func (a *account) Downvote(name string) error {
+	return a.DownvoteContext(context.Background(), name)
+}
+
+func (a *account) DownvoteContext(ctx context.Context, name string) error {
+	return a.vote(ctx, name, downvote)
+}
+
+func (a *account) ClearVote(name string) error {
+	return a.ClearVoteContext(context.Background(), name)
+}
+
+func (a *account) ClearVoteContext(ctx context.Context, name string) error {
+	return a.vote(ctx, name, clearVote)
+}
+
+func (a *account) vote(ctx context.Context, name, dir string) error {
+	return a.r.sow(
+		ctx, "/api/vote", map[string]string{
+			"id":  name,
+			"dir": dir,
+		},
+	)
+}
+
+func (a *account) Edit(name, text string) (Submission, error) {
+	return a.EditContext(context.Background(), name, text)
+}
+
+func (a *account) EditContext(
+	ctx context.Context,
+	name, text string,
+) (Submission, error) {
+	return a.r.get_sow(
+		ctx, "/api/editusertext", map[string]string{
+			"thing_id": name,
+			"text":     text,
+		},
+	)
+}
+
+func (a *account) Delete(name string) error {
+	return a.DeleteContext(context.Background(), name)
+}
+
+func (a *account) DeleteContext(ctx context.Context, name string) error {
+	return a.r.sow(
+		ctx, "/api/del", map[string]string{
+			"id": name,
+		},
+	)
+}
+
+func (a *account) LinkFlairTemplates(subreddit string) ([]*FlairTemplate, error) {
+	return a.LinkFlairTemplatesContext(context.Background(), subreddit)
+}
+
+func (a *account) LinkFlairTemplatesContext(
+	ctx context.Context,
+	subreddit string,
+) ([]*FlairTemplate, error) {
+	return a.flairTemplates(ctx, "/r/"+subreddit+"/api/link_flair_v2")
+}
+
+func (a *account) UserFlairTemplates(subreddit string) ([]*FlairTemplate, error) {
+	return a.UserFlairTemplatesContext(context.Background(), subreddit)
+}
+
+func (a *account) UserFlairTemplatesContext(
+	ctx context.Context,
+	subreddit string,
+) ([]*FlairTemplate, error) {
+	return a.flairTemplates(ctx, "/r/"+subreddit+"/api/user_flair_v2")
+}
+
+func (a *account) flairTemplates(
+	ctx context.Context,
+	path string,
+) ([]*FlairTemplate, error) {
+	templates := []*FlairTemplate{}
+	if err := a.r.reap_json(ctx, path, nil, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (a *account) SelectFlair(name, templateID, text string) error {
+	return a.SelectFlairContext(context.Background(), name, templateID, text)
+}
+
+func (a *account) SelectFlairContext(
+	ctx context.Context,
+	name, templateID, text string,
+) error {
+	return a.r.sow(
+		ctx, "/api/selectflair", map[string]string{
+			"link":              name,
+			"flair_template_id": templateID,
+			"text":              text,
+		},
+	)
+}
+
+func (a *account) SetUserFlair(subreddit, user, templateID, text string) error {
+	return a.SetUserFlairContext(
+		context.Background(),
+		subreddit,
+		user,
+		templateID,
+		text,
+	)
+}
+
+func (a *account) SetUserFlairContext(
+	ctx context.Context,
+	subreddit, user, templateID, text string,
+) error {
+	return a.r.sow(
+		ctx, "/r/"+subreddit+"/api/selectflair", map[string]string{
+			"name":              user,
+			"flair_template_id": templateID,
+			"text":              text,
+		},
+	)
+}
+
+func (a *account) Me() (*Redditor, error) {
+	return a.MeContext(context.Background())
+}
+
+func (a *account) MeContext(ctx context.Context) (*Redditor, error) {
+	// Unlike /user/{name}/about, this endpoint does not wrap the account
+	// in a thing.
+	var data map[string]interface{}
+	if err := a.r.reap_json(
+		ctx, "/api/v1/me", map[string]string{"raw_json": "1"}, &data,
+	); err != nil {
+		return nil, err
+	}
+
+	return parseRedditor(&thing{Kind: redditorKind, Data: data})
+}
@@ -1,11 +1,20 @@
 package reddit
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2"
+)
+
+// authURL is the url of reddit's oauth2 authorization page.
+const authURL = "https://www.reddit.com/api/v1/authorize"
 
 var (
 	errMissingOauthCredentials = fmt.Errorf("missing oauth credentials")
 	errMissingUsername         = fmt.Errorf("missing username")
 	errMissingPassword         = fmt.Errorf("missing password")
+	errMissingRefreshToken     = fmt.Errorf("missing refresh token")
 )
 
 // App holds all the information needed to identify as a registered app on
@@ -14,7 +23,8 @@ var (
 // https://github.com/reddit/reddit/wiki/OAuth2
 type App struct {
 	// ID and Secret are used to claim an OAuth2 grant the bot's account
-	// previously authorized.
+	// previously authorized. Apps registered as "installed app" have no
+	// secret, and can only authorize with a RefreshToken or Tokens.
 	ID     string
 	Secret string
 
@@ -22,12 +32,95 @@ type App struct {
 	Username string
 	Password string
 
-	// tokenURL is the url of the token request location for OAuth2.
-	tokenURL string
+	// RefreshToken is a permanent refresh token granted to the app through
+	// the authorization code flow (see AuthCodeURL and Exchange). It is
+	// used instead of Username and Password, and works for accounts with
+	// two factor authentication.
+	RefreshToken string
+	// RedirectURL is the redirect uri registered for the app. It is needed
+	// to complete the authorization code flow.
+	RedirectURL string
+	// Tokens, if set, stores the tokens of the authorization code flow so
+	// they survive restarts. A stored token is used in place of
+	// RefreshToken, and tokens are saved whenever they are refreshed.
+	Tokens TokenStore
+
+	// Scopes are the OAuth2 scopes the app requests, such as "modposts" or
+	// "vote". If empty, DefaultScopes are requested. Requests to endpoints
+	// outside the scopes Reddit grants fail with InsufficientScopeErr.
+	Scopes []string
+
+	// TokenURL is the url of the token request location for OAuth2. If
+	// empty, Reddit's is used; set it to claim tokens from a stand-in
+	// service, such as in tests.
+	TokenURL string
+}
+
+// AuthCodeURL returns the address of Reddit's page where an account can grant
+// the app permanent access. After the grant Reddit redirects to RedirectURL
+// with the given state and a code to give to Exchange.
+func (a App) AuthCodeURL(state string) string {
+	return a.oauthConfig().AuthCodeURL(
+		state,
+		oauth2.SetAuthURLParam("duration", "permanent"),
+	)
+}
+
+// Exchange trades a code from the authorization code flow for a token, which
+// holds the app's refresh token. If Tokens is set, the token is saved there.
+// Requests are made with the given user agent.
+func (a App) Exchange(ctx context.Context, agent, code string) (
+	*oauth2.Token,
+	error,
+) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, clientWithAgent(agent))
+	token, err := a.oauthConfig().Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Tokens != nil {
+		return token, a.Tokens.SetToken(token)
+	}
+
+	return token, nil
+}
+
+// oauthConfig returns the OAuth2 configuration of the app.
+func (a App) oauthConfig() *oauth2.Config {
+	tokenURL := a.TokenURL
+	if tokenURL == "" {
+		tokenURL = defaultTokenURL
+	}
+
+	return &oauth2.Config{
+		ClientID:     a.ID,
+		ClientSecret: a.Secret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   authURL,
+			TokenURL:  tokenURL,
+			AuthStyle: oauth2.AuthStyleInHeader,
+		},
+		RedirectURL: a.RedirectURL,
+		Scopes:      a.scopes(),
+	}
+}
+
+// scopes returns the OAuth2 scopes the app requests.
+func (a App) scopes() []string {
+	if len(a.Scopes) == 0 {
+		return DefaultScopes
+	}
+	return a.Scopes
+}
+
+// authCode is true when the app authorizes with the authorization code flow.
+func (a App) authCode() bool {
+	return a.RefreshToken != "" || a.Tokens != nil
 }
 
 func (a App) unauthenticated() bool {
-	return a.ID == "" || a.Secret == ""
+	return a.ID == "" || (a.Secret == "" && !a.authCode())
 }
 
 func (a App) validateAuth() error {
@@ -35,6 +128,14 @@ func (a App) validateAuth() error {
 		return errMissingOauthCredentials
 	}
 
+	if err := validateScopes(a.Scopes); err != nil {
+		return err
+	}
+
+	if a.authCode() {
+		return nil
+	}
+
 	if a.Password != "" && a.Username == "" {
 		return errMissingUsername
 	}
@@ -1,6 +1,10 @@
 package reddit
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -9,12 +13,12 @@ func TestAppUnauthenticated(t *testing.T) {
 		input  App
 		output bool
 	}{
-		{App{"y", "", "", "", ""}, true},
-		{App{"", "y", "", "", ""}, true},
-		{App{"y", "y", "", "", ""}, false},
-		{App{"y", "y", "y", "", ""}, false},
-		{App{"y", "y", "", "y", ""}, false},
-		{App{"y", "y", "y", "y", ""}, false},
+		{App{ID: "y"}, true},
+		{App{Secret: "y"}, true},
+		{App{ID: "y", Secret: "y"}, false},
+		{App{ID: "y", Secret: "y", Username: "y"}, false},
+		{App{ID: "y", Secret: "y", Password: "y"}, false},
+		{App{ID: "y", Secret: "y", Username: "y", Password: "y"}, false},
 	} {
 		if actual := test.input.unauthenticated(); actual != test.output {
 			t.Errorf("wrong on %d; wanted %v", i, test.output)
@@ -27,16 +31,135 @@ func TestAppValidateAuth(t *testing.T) {
 		input  App
 		output error
 	}{
-		{App{"", "", "", "", ""}, errMissingOauthCredentials},
-		{App{"y", "", "", "", ""}, errMissingOauthCredentials},
-		{App{"", "y", "", "", ""}, errMissingOauthCredentials},
-		{App{"y", "y", "y", "", ""}, errMissingPassword},
-		{App{"y", "y", "", "y", ""}, errMissingUsername},
-		{App{"y", "y", "", "", ""}, nil},
-		{App{"y", "y", "y", "y", ""}, nil},
+		{App{}, errMissingOauthCredentials},
+		{App{ID: "y"}, errMissingOauthCredentials},
+		{App{Secret: "y"}, errMissingOauthCredentials},
+		{App{ID: "y", Secret: "y", Username: "y"}, errMissingPassword},
+		{App{ID: "y", Secret: "y", Password: "y"}, errMissingUsername},
+		{App{ID: "y", Secret: "y"}, nil},
+		{App{ID: "y", Secret: "y", Username: "y", Password: "y"}, nil},
 	} {
 		if actual := test.input.validateAuth(); actual != test.output {
 			t.Errorf("wrong on %d; wanted %v", i, test.output)
 		}
 	}
 }
+
+func TestAppAuthCode(t *testing.T) {
+	installed := App{ID: "y", RefreshToken: "y"}
+	if installed.unauthenticated() {
+		t.Errorf("wanted installed app with refresh token authenticated")
+	}
+
+	if err := installed.validateAuth(); err != nil {
+		t.Errorf("unexpected error validating installed app: %v", err)
+	}
+
+	stored := App{ID: "y", Secret: "y", Tokens: NewFileTokenStore("")}
+	if err := stored.validateAuth(); err != nil {
+		t.Errorf("unexpected error validating app with token store: %v", err)
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	app := App{ID: "id", RedirectURL: "http://localhost:8080/callback"}
+	u, err := url.Parse(app.AuthCodeURL("state"))
+	if err != nil {
+		t.Fatalf("failed to parse url: %v", err)
+	}
+
+	q := u.Query()
+	for key, value := range map[string]string{
+		"client_id":     "id",
+		"response_type": "code",
+		"state":         "state",
+		"duration":      "permanent",
+		"redirect_uri":  "http://localhost:8080/callback",
+	} {
+		if q.Get(key) != value {
+			t.Errorf("wanted %s=%s; got %s", key, value, q.Get(key))
+		}
+	}
+}
+
+func TestAppScopes(t *testing.T) {
+	if scopes := (App{}).scopes(); len(scopes) != len(DefaultScopes) {
+		t.Errorf("wanted default scopes; got %v", scopes)
+	}
+
+	modded := App{ID: "y", Secret: "y", Scopes: []string{"read", "modposts"}}
+	if err := modded.validateAuth(); err != nil {
+		t.Errorf("unexpected error validating scopes: %v", err)
+	}
+
+	if scopes := modded.scopes(); len(scopes) != 2 || scopes[1] != "modposts" {
+		t.Errorf("wanted configured scopes; got %v", scopes)
+	}
+
+	unknown := App{ID: "y", Secret: "y", Scopes: []string{"modpost"}}
+	if err := unknown.validateAuth(); err == nil {
+		t.Errorf("wanted error validating unknown scope")
+	}
+}
+
+func TestRequiredRelationshipScope(t *testing.T) {
+	r := &reaperImpl{hostname: "oauth.reddit.com", scheme: "https"}
+	for i, test := range []struct {
+		path  string
+		kind  string
+		scope string
+	}{
+		{"/r/sub/api/friend", banned, "modcontributors"},
+		{"/r/sub/api/unfriend", muted, "modcontributors"},
+		{"/r/sub/api/friend", contributor, "modcontributors"},
+		{"/r/sub/api/friend", moderatorInvite, "modothers"},
+		{"/r/sub/api/unfriend", moderatorType, "modothers"},
+		{"/r/sub/api/friend", "unknown", ""},
+	} {
+		req := r.post(test.path, map[string]string{
+			"name": "user",
+			"type": test.kind,
+		})
+		if scope := requiredScope(req); scope != test.scope {
+			t.Errorf("%d: got scope %q; wanted %q", i, scope, test.scope)
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil || !strings.Contains(string(body), "type="+test.kind) {
+			t.Errorf("%d: request body consumed by the scope check", i)
+		}
+	}
+}
+
+func TestRequiredScope(t *testing.T) {
+	for i, test := range []struct {
+		method string
+		path   string
+		scope  string
+	}{
+		{"POST", "/api/vote", "vote"},
+		{"GET", "/api/v1/me", "identity"},
+		{"GET", "/user/name/about.json", "read"},
+		{"GET", "/user/name/submitted.json", "history"},
+		{"GET", "/u/name/comments.json", "history"},
+		{"GET", "/u/name/about.json", "read"},
+		{"GET", "/api/info.json", "read"},
+		{"GET", "/r/sub/about/log.json", "modlog"},
+		{"GET", "/r/sub/about.json", "read"},
+		{"GET", "/api/mod/conversations", "modmail"},
+		{"GET", "/r/sub/about/banned.json", "read"},
+		{"GET", "/r/sub/about/muted.json", "read"},
+		{"GET", "/r/sub/about/contributors.json", "read"},
+		{"GET", "/r/sub/about/moderators.json", "read"},
+		{"POST", "/api/unknown", ""},
+		{"GET", "/api/unknown", ""},
+	} {
+		req := &http.Request{
+			Method: test.method,
+			URL:    &url.URL{Path: test.path},
+		}
+		if scope := requiredScope(req); scope != test.scope {
+			t.Errorf("%d: got scope %q; wanted %q", i, scope, test.scope)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package reddit
 
 import (
 	"net/http"
+	"sync"
 	"time"
 
 	"golang.org/x/net/context"
@@ -9,69 +10,164 @@ import (
 	"golang.org/x/oauth2/clientcredentials"
 )
 
-var oauthScopes = []string{
-	"identity",
-	"read",
-	"privatemessages",
-	"submit",
-	"history",
-}
+// refreshEarly is how long before a token expires it is replaced, so requests
+// never carry a token which expires in flight.
+const refreshEarly = time.Minute * 5
 
+// appClient is a client authorized as a registered app. Its token is managed
+// by a token source which is safe for use by many goroutines, so the client is
+// too.
 type appClient struct {
 	baseClient
 	cfg    clientConfig
 	cli    *http.Client
-	expiry time.Time
+	tokens oauth2.TokenSource
 }
 
 func (a *appClient) Do(req *http.Request) ([]byte, error) {
-	if time.Until(a.expiry) < time.Minute*5 {
-		if err := a.authorize(); err != nil {
-			return nil, err
-		}
+	token, err := a.tokens.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkScope(grantedScopes(token.Extra("scope")), req); err != nil {
+		return nil, err
 	}
 
 	return a.baseClient.Do(req)
 }
 
+// scopes returns the scopes Reddit granted the app, or nil if they are not
+// known.
+func (a *appClient) scopes() map[string]bool {
+	token, err := a.tokens.Token()
+	if err != nil {
+		return nil
+	}
+	return grantedScopes(token.Extra("scope"))
+}
+
 func (a *appClient) authorize() error {
 	ctx := context.WithValue(oauth2.NoContext, oauth2.HTTPClient, a.cli)
 
-	if a.cfg.app.Username == "" || a.cfg.app.Password == "" {
-		a.baseClient.cli = a.clientCredentialsClient(ctx)
-		return nil
+	var err error
+	switch {
+	case a.cfg.app.authCode():
+		a.tokens, err = a.refreshTokenSource(ctx)
+	case a.cfg.app.Username == "" || a.cfg.app.Password == "":
+		a.tokens = a.clientCredentialsTokenSource(ctx)
+	default:
+		a.tokens, err = a.passwordTokenSource(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	a.baseClient.cli = &http.Client{
+		Transport: &oauth2.Transport{Source: a.tokens, Base: a.cli.Transport},
 	}
+	return nil
+}
 
+// passwordTokenSource returns a token source which claims tokens with the
+// app's username and password.
+func (a *appClient) passwordTokenSource(ctx context.Context) (
+	oauth2.TokenSource,
+	error,
+) {
 	cfg := &oauth2.Config{
 		ClientID:     a.cfg.app.ID,
 		ClientSecret: a.cfg.app.Secret,
-		Endpoint:     oauth2.Endpoint{TokenURL: a.cfg.app.tokenURL},
-		Scopes:       oauthScopes,
+		Endpoint:     oauth2.Endpoint{TokenURL: a.cfg.app.TokenURL},
+		Scopes:       a.cfg.app.scopes(),
 	}
 
-	token, err := cfg.PasswordCredentialsToken(
-		ctx,
-		a.cfg.app.Username,
-		a.cfg.app.Password,
-	)
-	if err != nil {
-		return err
+	src := &refreshingTokenSource{
+		fetch: func(_ *oauth2.Token) (*oauth2.Token, error) {
+			return cfg.PasswordCredentialsToken(
+				ctx,
+				a.cfg.app.Username,
+				a.cfg.app.Password,
+			)
+		},
+		early: refreshEarly,
+		mu:    &sync.Mutex{},
 	}
 
-	a.baseClient.cli = cfg.Client(ctx, token)
-	a.expiry = token.Expiry
-	return err
+	// Claim a token now so bad credentials are reported on creation.
+	_, err := src.Token()
+	return src, err
 }
 
-func (a *appClient) clientCredentialsClient(ctx context.Context) *http.Client {
+// refreshTokenSource returns a token source which claims tokens with a
+// refresh token from the authorization code flow, preferring one in the app's
+// token store. Refreshed tokens are saved to the store.
+func (a *appClient) refreshTokenSource(ctx context.Context) (
+	oauth2.TokenSource,
+	error,
+) {
+	token := &oauth2.Token{RefreshToken: a.cfg.app.RefreshToken}
+	if a.cfg.app.Tokens != nil {
+		stored, err := a.cfg.app.Tokens.Token()
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil && stored.RefreshToken != "" {
+			token = stored
+		}
+	}
+
+	if token.RefreshToken == "" {
+		return nil, errMissingRefreshToken
+	}
+
+	cfg := a.cfg.app.oauthConfig()
+	var src oauth2.TokenSource = &refreshingTokenSource{
+		fetch: func(current *oauth2.Token) (*oauth2.Token, error) {
+			// A token source given no access token refreshes at
+			// once.
+			return cfg.TokenSource(
+				ctx,
+				&oauth2.Token{RefreshToken: current.RefreshToken},
+			).Token()
+		},
+		early: refreshEarly,
+		token: token,
+		mu:    &sync.Mutex{},
+	}
+	if a.cfg.app.Tokens != nil {
+		src = &storingTokenSource{
+			src:   src,
+			store: a.cfg.app.Tokens,
+			last:  token.AccessToken,
+			mu:    &sync.Mutex{},
+		}
+	}
+
+	// Claim a token now so bad credentials are reported on creation.
+	_, err := src.Token()
+	return src, err
+}
+
+// clientCredentialsTokenSource returns a token source which claims tokens for
+// the app itself rather than an account.
+func (a *appClient) clientCredentialsTokenSource(
+	ctx context.Context,
+) oauth2.TokenSource {
 	cfg := &clientcredentials.Config{
 		ClientID:     a.cfg.app.ID,
 		ClientSecret: a.cfg.app.Secret,
-		TokenURL:     a.cfg.app.tokenURL,
-		Scopes:       oauthScopes,
+		TokenURL:     a.cfg.app.TokenURL,
+		Scopes:       a.cfg.app.scopes(),
 	}
 
-	return cfg.Client(ctx)
+	return &refreshingTokenSource{
+		fetch: func(_ *oauth2.Token) (*oauth2.Token, error) {
+			return cfg.Token(ctx)
+		},
+		early: refreshEarly,
+		mu:    &sync.Mutex{},
+	}
 }
 
 func newAppClient(c clientConfig) (*appClient, error) {
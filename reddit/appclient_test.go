@@ -0,0 +1,276 @@
+package reddit
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// tokenServer serves an OAuth2 token endpoint at /token which grants access
+// tokens with the "read" scope for the refresh token "refresh", which expire
+// after the given number of seconds, and an endpoint at /api which responds
+// "ok" to requests bearing a granted access token.
+func tokenServer(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+	var grants int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if id, _, ok := r.BasicAuth(); !ok || id != "id" {
+			t.Errorf("token request missing client credentials")
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse token request: %v", err)
+		}
+
+		if r.PostForm.Get("grant_type") != "refresh_token" ||
+			r.PostForm.Get("refresh_token") != "refresh" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(
+			w,
+			`{"access_token": "access%d", "token_type": "bearer", "expires_in": %d, "scope": "read"}`,
+			atomic.AddInt32(&grants, 1),
+			expiresIn,
+		)
+	})
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		var n int32
+		if _, err := fmt.Sscanf(
+			r.Header.Get("Authorization"),
+			"Bearer access%d",
+			&n,
+		); err != nil || n < 1 || n > atomic.LoadInt32(&grants) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	return httptest.NewServer(mux), &grants
+}
+
+func TestRefreshTokenClient(t *testing.T) {
+	serv, grants := tokenServer(t, 3600)
+	defer serv.Close()
+
+	dir, err := ioutil.TempDir("", "tokens")
+	if err != nil {
+		t.Fatalf("failed to make test directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	store := NewFileTokenStore(filepath.Join(dir, "token.json"))
+
+	cli, err := newClient(clientConfig{
+		agent: "agent",
+		app: App{
+			ID:           "id",
+			RefreshToken: "refresh",
+			Tokens:       store,
+			TokenURL:     serv.URL + "/token",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+
+	req, err := http.NewRequest("GET", serv.URL+"/api", nil)
+	if err != nil {
+		t.Fatalf("failed to prepare request for test: %v", err)
+	}
+
+	body, err := cli.Do(req)
+	if err != nil || string(body) != "ok" {
+		t.Errorf("request failed: %s, %v", body, err)
+	}
+
+	if n := atomic.LoadInt32(grants); n != 1 {
+		t.Errorf("wanted 1 token grant; got %d", n)
+	}
+
+	token, err := store.Token()
+	if err != nil || token == nil {
+		t.Fatalf("wanted token stored; got %v, %v", token, err)
+	}
+
+	if token.AccessToken != "access1" || token.RefreshToken != "refresh" {
+		t.Errorf("stored token incorrect: %+v", token)
+	}
+}
+
+func TestRefreshTokenClientBadToken(t *testing.T) {
+	serv, _ := tokenServer(t, 3600)
+	defer serv.Close()
+
+	if _, err := newClient(clientConfig{
+		agent: "agent",
+		app: App{
+			ID:           "id",
+			RefreshToken: "wrong",
+			TokenURL:     serv.URL + "/token",
+		},
+	}); err == nil {
+		t.Errorf("wanted error authorizing with a bad refresh token")
+	}
+}
+
+func TestRefreshTokenClientScopes(t *testing.T) {
+	serv, _ := tokenServer(t, 3600)
+	defer serv.Close()
+
+	cli, err := newClient(clientConfig{
+		agent: "agent",
+		app: App{
+			ID:           "id",
+			RefreshToken: "refresh",
+			TokenURL:     serv.URL + "/token",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", serv.URL+"/api/compose", nil)
+	if err != nil {
+		t.Fatalf("failed to prepare request for test: %v", err)
+	}
+
+	if _, err := cli.Do(req); !errors.Is(err, InsufficientScopeErr) {
+		t.Errorf("wanted InsufficientScopeErr; got %v", err)
+	}
+
+	req, err = http.NewRequest("GET", serv.URL+"/api", nil)
+	if err != nil {
+		t.Fatalf("failed to prepare request for test: %v", err)
+	}
+
+	if body, err := cli.Do(req); err != nil || string(body) != "ok" {
+		t.Errorf("request in granted scope failed: %s, %v", body, err)
+	}
+}
+
+func TestBotScopedActions(t *testing.T) {
+	serv, _ := tokenServer(t, 3600)
+	defer serv.Close()
+
+	b, err := NewBot(BotConfig{
+		Agent: "agent",
+		App: App{
+			ID:           "id",
+			RefreshToken: "refresh",
+			TokenURL:     serv.URL + "/token",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+
+	if _, ok := b.Moderator(); ok {
+		t.Errorf("wanted no moderator actions without the modposts scope")
+	}
+
+	if _, ok := b.Modmail(); ok {
+		t.Errorf("wanted no modmail actions without the modmail scope")
+	}
+
+	// Clients which do not know their scopes are assumed to have them.
+	b = &bot{
+		moderator: newModerator(&mockReaper{}),
+		modmail:   newModmail(&mockReaper{}),
+		cli:       &mockClient{},
+	}
+	if m, ok := b.Moderator(); !ok || m == nil {
+		t.Errorf("wanted moderator actions when scopes are unknown")
+	}
+
+	if m, ok := b.Modmail(); !ok || m == nil {
+		t.Errorf("wanted modmail actions when scopes are unknown")
+	}
+}
+
+func TestRefreshTokenClientConcurrent(t *testing.T) {
+	// Tokens which expire sooner than they are refreshed are replaced
+	// before every request, so requests race with refreshes.
+	serv, grants := tokenServer(t, int(refreshEarly/time.Second)-1)
+	defer serv.Close()
+
+	cli, err := newClient(clientConfig{
+		agent: "agent",
+		app: App{
+			ID:           "id",
+			RefreshToken: "refresh",
+			TokenURL:     serv.URL + "/token",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+
+	const workers, requests = 20, 10
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < requests; j++ {
+				req, err := http.NewRequest("GET", serv.URL+"/api", nil)
+				if err != nil {
+					t.Errorf("failed to prepare request for test: %v", err)
+					return
+				}
+
+				if body, err := cli.Do(req); err != nil || string(body) != "ok" {
+					t.Errorf("request failed: %s, %v", body, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(grants); n < 2 {
+		t.Errorf("wanted tokens refreshed; got %d grants", n)
+	}
+}
+
+func TestRefreshingTokenSource(t *testing.T) {
+	fetches := 0
+	src := &refreshingTokenSource{
+		fetch: func(current *oauth2.Token) (*oauth2.Token, error) {
+			fetches++
+			return &oauth2.Token{
+				AccessToken: fmt.Sprintf("access%d", fetches),
+				Expiry:      time.Now().Add(time.Hour),
+			}, nil
+		},
+		early: time.Minute,
+		token: &oauth2.Token{RefreshToken: "refresh"},
+		mu:    &sync.Mutex{},
+	}
+
+	for i := 0; i < 3; i++ {
+		token, err := src.Token()
+		if err != nil {
+			t.Fatalf("failed to claim token: %v", err)
+		}
+
+		if token.AccessToken != "access1" || token.RefreshToken != "refresh" {
+			t.Errorf("%d: unexpected token %+v", i, token)
+		}
+	}
+
+	src.token.Expiry = time.Now().Add(time.Second * 30)
+	if token, err := src.Token(); err != nil || token.AccessToken != "access2" {
+		t.Errorf("wanted token refreshed before expiry; got %+v, %v", token, err)
+	}
+}
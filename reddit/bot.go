@@ -2,6 +2,7 @@ package reddit
 
 import (
 	"net/http"
+	"os"
 	"time"
 )
 
@@ -21,6 +22,10 @@ type BotConfig struct {
 	Rate time.Duration
 	// Custom HTTP client
 	Client *http.Client
+	// Retry configures how requests which fail with transient errors are
+	// retried. If unset, DefaultRetryPolicy is used; set MaxAttempts to 1
+	// to disable retries.
+	Retry RetryPolicy
 }
 
 // Bot defines the behaviors of a logged in Reddit bot.
@@ -28,12 +33,50 @@ type Bot interface {
 	Account
 	Lurker
 	Scanner
+	RateLimited
+
+	// Moderator returns the bot's moderator actions. It returns false if
+	// Reddit did not grant the app the "modposts" scope most of them
+	// need. Actions which need other scopes, such as banning users
+	// ("modcontributors") or inviting moderators ("modothers"), still
+	// fail with InsufficientScopeErr if those were not granted.
+	Moderator() (Moderator, bool)
+	// Modmail returns the bot's modmail actions. It returns false if
+	// Reddit did not grant the app the "modmail" scope they need.
+	Modmail() (Modmail, bool)
 }
 
 type bot struct {
 	Account
 	Lurker
 	Scanner
+	RateLimited
+
+	moderator Moderator
+	modmail   Modmail
+	// cli is the client requests are made with, which knows the granted
+	// scopes if it is authorized as an app.
+	cli client
+}
+
+func (b *bot) Moderator() (Moderator, bool) {
+	return b.moderator, b.granted("modposts")
+}
+
+func (b *bot) Modmail() (Modmail, bool) {
+	return b.modmail, b.granted("modmail")
+}
+
+// granted reports whether the bot's app was granted a scope. If the granted
+// scopes are not known, it is assumed they were.
+func (b *bot) granted(scope string) bool {
+	s, ok := b.cli.(scopedClient)
+	if !ok {
+		return true
+	}
+
+	granted := s.scopes()
+	return granted == nil || granted[allScopes] || granted[scope]
 }
 
 // NewBot returns a logged in handle to the Reddit API.
@@ -46,29 +89,50 @@ func NewBot(c BotConfig) (Bot, error) {
 			hostname: "oauth.reddit.com",
 			tls:      true,
 			rate:     maxOf(c.Rate, time.Second),
+			retry:    c.Retry.orDefault(),
 		},
 	)
 	return &bot{
-		Account: newAccount(r),
-		Lurker:  newLurker(r),
-		Scanner: newScanner(r),
+		Account:     newAccount(r),
+		Lurker:      newLurker(r),
+		Scanner:     newScanner(r),
+		RateLimited: cli,
+		moderator:   newModerator(r),
+		modmail:     newModmail(r),
+		cli:         cli,
 	}, err
 }
 
 // NewBotFromAgentFile calls NewBot with a config built from an agent file. An
 // agent file is a convenient way to store your bot's account information. See
 // https://github.com/turnage/graw/wiki/agent-files
+//
+// Agent files ending in .yaml, .yml or .json are read in those formats, with
+// the fields user_agent, client_id, client_secret, username, password,
+// refresh_token, scopes, token_url and retry_attempts. retry_attempts sets the
+// MaxAttempts of DefaultRetryPolicy; 1 disables retries. Files in the legacy
+// format may set refresh_token, token_url and retry_attempts too.
 func NewBotFromAgentFile(filename string, rate time.Duration) (Bot, error) {
-	agent, app, err := load(filename)
+	c, err := load(filename)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewBot(
-		BotConfig{
-			Agent: agent,
-			App:   app,
-			Rate:  rate,
-		},
-	)
+	c.Rate = rate
+	return NewBot(c)
+}
+
+// NewBotFromEnv calls NewBot with a config built from the environment
+// variables GRAW_USER_AGENT, GRAW_CLIENT_ID, GRAW_CLIENT_SECRET,
+// GRAW_USERNAME, GRAW_PASSWORD, GRAW_REFRESH_TOKEN, GRAW_SCOPES (a comma
+// separated list) and GRAW_RETRY_ATTEMPTS (as retry_attempts in an agent
+// file), so secrets can be given to the bot without a file.
+func NewBotFromEnv(rate time.Duration) (Bot, error) {
+	c, err := loadEnv(os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Rate = rate
+	return NewBot(c)
 }
@@ -2,12 +2,18 @@ package reddit
 
 import (
 	"bytes"
-	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"sync"
+	"time"
 )
 
-// tokenURL is the url of reddit's oauth2 authorization service.
-const tokenURL = "https://www.reddit.com/api/v1/access_token"
+// defaultTokenURL is the url of reddit's oauth2 authorization service.
+const defaultTokenURL = "https://www.reddit.com/api/v1/access_token"
+
+// maxErrorBody is the most of an error response's body read for details.
+const maxErrorBody = 1 << 16
 
 // clientConfig holds all the information needed to define Client behavior, such
 // as who the client will identify as externally and where to authorize.
@@ -23,13 +29,28 @@ type clientConfig struct {
 	client *http.Client
 }
 
-// client executes http Requests and invisibly handles OAuth2 authorization.
+// client executes http Requests and invisibly handles OAuth2 authorization. It
+// keeps track of the rate limit budget Reddit reports in its responses.
 type client interface {
 	Do(*http.Request) ([]byte, error)
+	RateLimited
+}
+
+// scopedClient is a client which knows the OAuth2 scopes it was granted.
+type scopedClient interface {
+	client
+	// scopes returns the granted scopes, or nil if they are not known.
+	scopes() map[string]bool
 }
 
 type baseClient struct {
 	cli *http.Client
+
+	// limit is the rate limit budget reported in the most recent response
+	// which had one, and hasLimit is whether there has been one.
+	limit    RateLimit
+	hasLimit bool
+	mu       sync.Mutex
 }
 
 func (b *baseClient) Do(req *http.Request) ([]byte, error) {
@@ -41,20 +62,16 @@ func (b *baseClient) Do(req *http.Request) ([]byte, error) {
 		return nil, err
 	}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-	case http.StatusForbidden:
-		return nil, PermissionDeniedErr
-	case http.StatusServiceUnavailable:
-		return nil, BusyErr
-	case http.StatusTooManyRequests:
-		return nil, RateLimitErr
-	case http.StatusBadGateway:
-		return nil, GatewayErr
-	case http.StatusGatewayTimeout:
-		return nil, GatewayTimeoutErr
-	default:
-		return nil, fmt.Errorf("bad response code: %d", resp.StatusCode)
+	if limit, ok := parseRateLimit(resp.Header, time.Now()); ok {
+		b.mu.Lock()
+		b.limit, b.hasLimit = limit, true
+		b.mu.Unlock()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// Error responses may describe the error in a JSON body.
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return nil, statusError(resp.StatusCode, resp.Header, body)
 	}
 
 	var buf bytes.Buffer
@@ -65,14 +82,20 @@ func (b *baseClient) Do(req *http.Request) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+func (b *baseClient) RateLimit() (RateLimit, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limit, b.hasLimit
+}
+
 // newClient returns a new client using the given user to make requests.
 func newClient(c clientConfig) (client, error) {
-	if c.app.tokenURL == "" {
-		c.app.tokenURL = tokenURL
+	if c.app.TokenURL == "" {
+		c.app.TokenURL = defaultTokenURL
 	}
 
 	if c.app.unauthenticated() {
-		return &baseClient{clientWithAgent(c.agent)}, nil
+		return &baseClient{cli: clientWithAgent(c.agent)}, nil
 	}
 
 	if err := c.app.validateAuth(); err != nil {
@@ -1,6 +1,7 @@
 package reddit
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -50,7 +51,7 @@ func TestDo(t *testing.T) {
 		}
 
 		body, err := r.Do(req)
-		if err != test.err {
+		if !errors.Is(err, test.err) {
 			t.Errorf("unexpected error: %v", err)
 		} else if len(body) != len(test.body) {
 			t.Errorf(
@@ -37,6 +37,9 @@ type Comment struct {
 
 	Gilded        int32  `mapstructure:"gilded"`
 	Distinguished string `mapstructure:"distinguished"`
+
+	// NumReports is only visible to moderators of the subreddit.
+	NumReports int32 `mapstructure:"num_reports"`
 }
 
 // IsTopLevel is true when the comment is a top level comment.
@@ -112,6 +115,9 @@ type Post struct {
 	Hidden            bool   `mapstructure:"hidden"`
 	LinkFlairCSSClass string `mapstructure:"link_flair_css_class"`
 	LinkFlairText     string `mapstructure:"link_flair_text"`
+	// LinkFlairTemplateID is the id of the FlairTemplate the post's flair
+	// was selected from, if any.
+	LinkFlairTemplateID string `mapstructure:"link_flair_template_id"`
 
 	NumComments int32  `mapstructure:"num_comments"`
 	Locked      bool   `mapstructure:"locked"`
@@ -121,6 +127,9 @@ type Post struct {
 	Distinguished string `mapstructure:"distinguished"`
 	Stickied      bool   `mapstructure:"stickied"`
 
+	// NumReports is only visible to moderators of the subreddit.
+	NumReports int32 `mapstructure:"num_reports"`
+
 	IsRedditMediaDomain bool  `mapstructure:"is_reddit_media_domain"`
 	Media               Media `mapstructure:"media"`
 	SecureMedia         Media `mapstructure:"secure_media"`
@@ -151,6 +160,150 @@ type Message struct {
 	WasComment bool   `mapstructure:"was_comment"`
 }
 
+// ModAction represents an entry in a subreddit's moderation log (Reddit type
+// modaction).
+type ModAction struct {
+	ID string `mapstructure:"id"`
+	// Name is the same as ID. Mod actions have no full name, but they are
+	// identified by their ID in listings.
+	Name string
+
+	CreatedUTC uint64 `mapstructure:"created_utc"`
+
+	// Action is the kind of action taken, e.g. "removelink",
+	// "approvecomment", "banuser" or "editflair".
+	Action string `mapstructure:"action"`
+	Mod    string `mapstructure:"mod"`
+
+	// TargetFullname is the name of the post or comment acted on, if any.
+	TargetFullname  string `mapstructure:"target_fullname"`
+	TargetAuthor    string `mapstructure:"target_author"`
+	TargetTitle     string `mapstructure:"target_title"`
+	TargetBody      string `mapstructure:"target_body"`
+	TargetPermalink string `mapstructure:"target_permalink"`
+
+	Details     string `mapstructure:"details"`
+	Description string `mapstructure:"description"`
+
+	Subreddit   string `mapstructure:"subreddit"`
+	SubredditID string `mapstructure:"sr_id36"`
+}
+
+// Subreddit represents a subreddit and its settings (Reddit type t5).
+type Subreddit struct {
+	ID   string `mapstructure:"id"`
+	Name string `mapstructure:"name"`
+
+	CreatedUTC uint64 `mapstructure:"created_utc"`
+
+	// DisplayName is the name of the subreddit as it appears in its URL,
+	// e.g. "golang".
+	DisplayName string `mapstructure:"display_name"`
+	Title       string `mapstructure:"title"`
+	URL         string `mapstructure:"url"`
+	Lang        string `mapstructure:"lang"`
+
+	PublicDescription string `mapstructure:"public_description"`
+	// Description is the markdown of the subreddit's sidebar.
+	Description     string `mapstructure:"description"`
+	DescriptionHTML string `mapstructure:"description_html"`
+	SubmitText      string `mapstructure:"submit_text"`
+
+	Subscribers     int64 `mapstructure:"subscribers"`
+	ActiveUserCount int64 `mapstructure:"active_user_count"`
+
+	// SubredditType is "public", "private", "restricted", "gold_restricted"
+	// or "archived".
+	SubredditType string `mapstructure:"subreddit_type"`
+	// SubmissionType is the kind of posts the subreddit allows: "any",
+	// "link" or "self".
+	SubmissionType string `mapstructure:"submission_type"`
+	NSFW           bool   `mapstructure:"over18"`
+	Quarantine     bool   `mapstructure:"quarantine"`
+
+	SpoilersEnabled      bool  `mapstructure:"spoilers_enabled"`
+	LinkFlairEnabled     bool  `mapstructure:"link_flair_enabled"`
+	UserFlairEnabled     bool  `mapstructure:"user_flair_enabled_in_sr"`
+	AllowImages          bool  `mapstructure:"allow_images"`
+	AllowVideos          bool  `mapstructure:"allow_videos"`
+	CommentScoreHideMins int32 `mapstructure:"comment_score_hide_mins"`
+
+	// These describe the account making the request, and are false for
+	// logged out requests.
+	UserIsModerator   bool `mapstructure:"user_is_moderator"`
+	UserIsContributor bool `mapstructure:"user_is_contributor"`
+	UserIsBanned      bool `mapstructure:"user_is_banned"`
+	UserIsMuted       bool `mapstructure:"user_is_muted"`
+	UserIsSubscriber  bool `mapstructure:"user_is_subscriber"`
+}
+
+// Rule represents one of a subreddit's rules.
+type Rule struct {
+	ShortName       string `mapstructure:"short_name"`
+	Description     string `mapstructure:"description"`
+	DescriptionHTML string `mapstructure:"description_html"`
+	// ViolationReason is the reason shown in reports and removals for
+	// breaking the rule.
+	ViolationReason string `mapstructure:"violation_reason"`
+	// Kind is what the rule applies to: "link", "comment" or "all".
+	Kind     string `mapstructure:"kind"`
+	Priority int    `mapstructure:"priority"`
+
+	CreatedUTC uint64 `mapstructure:"created_utc"`
+}
+
+// Redditor represents a user's account (Reddit type t2).
+type Redditor struct {
+	ID string `mapstructure:"id"`
+	// Name is the user's username. Unlike other things, users are named
+	// by their username rather than their full name.
+	Name string `mapstructure:"name"`
+
+	// CreatedUTC is when the account was made; compare it with the
+	// current time to find the account's age.
+	CreatedUTC uint64 `mapstructure:"created_utc"`
+
+	LinkKarma    int64 `mapstructure:"link_karma"`
+	CommentKarma int64 `mapstructure:"comment_karma"`
+	TotalKarma   int64 `mapstructure:"total_karma"`
+
+	HasVerifiedEmail bool `mapstructure:"has_verified_email"`
+	// IsSuspended is true for suspended accounts, of which Reddit shares
+	// little more than their name.
+	IsSuspended bool `mapstructure:"is_suspended"`
+	IsEmployee  bool `mapstructure:"is_employee"`
+	IsMod       bool `mapstructure:"is_mod"`
+	IsGold      bool `mapstructure:"is_gold"`
+	Verified    bool `mapstructure:"verified"`
+
+	IconImg string `mapstructure:"icon_img"`
+}
+
+// Relationship represents a user's standing in a subreddit, such as a ban, a
+// mute, or being an approved contributor or moderator.
+type Relationship struct {
+	// ID is the id of the relationship itself.
+	ID string `mapstructure:"rel_id"`
+	// Name is the username of the user, and UserID the full name of
+	// their account.
+	Name   string `mapstructure:"name"`
+	UserID string `mapstructure:"id"`
+
+	// DateUTC is when the relationship began.
+	DateUTC uint64 `mapstructure:"date"`
+	// Note is the note moderators left on the relationship, e.g. the
+	// reason for a ban.
+	Note string `mapstructure:"note"`
+
+	// DaysLeft is the number of days left in a temporary ban, and
+	// Permanent is true for permanent bans.
+	DaysLeft  int `mapstructure:"days_left"`
+	Permanent bool
+	// ModPermissions are the permissions of a moderator, e.g. "all" or
+	// "posts".
+	ModPermissions []string `mapstructure:"mod_permissions"`
+}
+
 // More represents a more comments list on Reddit
 // https://github.com/reddit-archive/reddit/wiki/JSON#more
 type More struct {
@@ -172,10 +325,13 @@ type More struct {
 // that can be a lot of data. If you want a full comment tree, use the
 // `Thread` method on the bot with the post's `Permalink`.
 type Harvest struct {
-	Comments []*Comment
-	Posts    []*Post
-	Messages []*Message
-	Mores    []*More
+	Comments   []*Comment
+	Posts      []*Post
+	Messages   []*Message
+	Mores      []*More
+	ModActions []*ModAction
+	Subreddits []*Subreddit
+	Redditors  []*Redditor
 }
 
 type Submission struct {
@@ -1,14 +1,209 @@
 package reddit
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var (
-	PermissionDeniedErr   = fmt.Errorf("unauthorized access to endpoint")
-	BusyErr               = fmt.Errorf("Reddit is busy right now")
-	RateLimitErr          = fmt.Errorf("Reddit is rate limiting requests")
-	GatewayErr            = fmt.Errorf("502 bad gateway code from Reddit")
-	GatewayTimeoutErr     = fmt.Errorf("504 gateway timeout from Reddit")
-	ThreadDoesNotExistErr = fmt.Errorf("The requested post does not exist.")
+	PermissionDeniedErr      = fmt.Errorf("unauthorized access to endpoint")
+	BusyErr                  = fmt.Errorf("Reddit is busy right now")
+	RateLimitErr             = fmt.Errorf("Reddit is rate limiting requests")
+	GatewayErr               = fmt.Errorf("502 bad gateway code from Reddit")
+	GatewayTimeoutErr        = fmt.Errorf("504 gateway timeout from Reddit")
+	ThreadDoesNotExistErr    = fmt.Errorf("The requested post does not exist.")
+	SubredditDoesNotExistErr = fmt.Errorf("The requested subreddit does not exist.")
+	RedditorDoesNotExistErr  = fmt.Errorf("The requested user does not exist.")
+	InsufficientScopeErr     = fmt.Errorf("the app was not granted the OAuth2 scope for the endpoint")
 )
+
+// insufficientScope is the error code for requests outside the granted scopes.
+const insufficientScope = "insufficient_scope"
+
+// statusErrs are the errors above which describe error response codes.
+var statusErrs = map[int]error{
+	http.StatusForbidden:          PermissionDeniedErr,
+	http.StatusServiceUnavailable: BusyErr,
+	http.StatusTooManyRequests:    RateLimitErr,
+	http.StatusBadGateway:         GatewayErr,
+	http.StatusGatewayTimeout:     GatewayTimeoutErr,
+}
+
+// tryAgainPattern matches the wait Reddit asks for in rate limit messages, e.g.
+// "you are doing that too much. try again in 5 minutes."
+var tryAgainPattern = regexp.MustCompile(
+	`try again in (\d+) (millisecond|second|minute|hour)`,
+)
+
+// APIError is an error reported by Reddit, either by an error response code or
+// in the "errors" array of a JSON response. It matches the error variables of
+// this package that describe it with errors.Is, e.g.
+//
+//   if errors.Is(err, reddit.RateLimitErr) { ... }
+//
+// and its details can be read with errors.As.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is Reddit's name for the error, such as "RATELIMIT",
+	// "SUBREDDIT_NOEXIST" or "THREAD_LOCKED", if it gave one.
+	Code string
+	// Message is Reddit's description of the error, if it gave one.
+	Message string
+	// Field is the request parameter the error concerns, if any.
+	Field string
+	// RetryAfter is how long Reddit asked to wait before trying again,
+	// either in a Retry-After header or in a message such as "try again
+	// in 5 minutes". It is 0 if Reddit did not say.
+	RetryAfter time.Duration
+	// Others are any further errors Reddit reported in the same response.
+	Others []*APIError
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" && e.Message == "" {
+		if err, ok := statusErrs[e.StatusCode]; ok {
+			return err.Error()
+		}
+		return fmt.Sprintf("bad response code: %d", e.StatusCode)
+	}
+
+	msg := e.Code
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Field != "" {
+		msg += " (" + e.Field + ")"
+	}
+	for _, o := range e.Others {
+		msg += "; " + o.Error()
+	}
+	return "Reddit error: " + msg
+}
+
+// Is reports whether the error is described by one of the error variables of
+// this package.
+func (e *APIError) Is(target error) bool {
+	if target == RateLimitErr && e.Has("RATELIMIT") {
+		return true
+	}
+
+	if target == InsufficientScopeErr && e.Has(insufficientScope) {
+		return true
+	}
+
+	err, ok := statusErrs[e.StatusCode]
+	return ok && err == target
+}
+
+// Has reports whether Reddit reported an error with the given code.
+func (e *APIError) Has(code string) bool {
+	if e.Code == code {
+		return true
+	}
+
+	for _, o := range e.Others {
+		if o.Has(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// statusBody is the JSON body Reddit sends with some error response codes,
+// e.g. when voting on an archived post:
+//
+//   {"reason": "TOO_OLD", "explanation": "...", "fields": ["id"], ...}
+type statusBody struct {
+	Reason      string   `json:"reason"`
+	Explanation string   `json:"explanation"`
+	Fields      []string `json:"fields"`
+}
+
+// statusError returns the error for an error response code, with the wait
+// asked for by a Retry-After header in seconds, if there is one. Requests
+// outside the granted scopes are identified by the WWW-Authenticate header,
+// and other errors by the reason in the body, if it has one.
+func statusError(code int, h http.Header, body []byte) *APIError {
+	e := &APIError{StatusCode: code}
+	if strings.Contains(h.Get("WWW-Authenticate"), insufficientScope) {
+		e.Code = insufficientScope
+	}
+
+	var details statusBody
+	if json.Unmarshal(body, &details) == nil && details.Reason != "" {
+		e.Code = details.Reason
+		e.Message = details.Explanation
+		if len(details.Fields) > 0 {
+			e.Field = details.Fields[0]
+		}
+	}
+	if seconds, err := strconv.Atoi(h.Get("Retry-After")); err == nil {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return e
+}
+
+// jsonErrors returns an APIError for the "errors" array of a JSON response, or
+// nil if it is empty. Each error in the array is a list of the form
+// [code, message, field].
+func jsonErrors(errs []interface{}) error {
+	var result *APIError
+	for _, raw := range errs {
+		e := &APIError{StatusCode: http.StatusOK}
+		if fields, ok := raw.([]interface{}); ok {
+			for i, f := range fields {
+				s, _ := f.(string)
+				switch i {
+				case 0:
+					e.Code = s
+				case 1:
+					e.Message = s
+				case 2:
+					e.Field = s
+				}
+			}
+		} else {
+			e.Message = fmt.Sprintf("%v", raw)
+		}
+		e.RetryAfter = tryAgainIn(e.Message)
+
+		if result == nil {
+			result = e
+		} else {
+			result.Others = append(result.Others, e)
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+	return result
+}
+
+// tryAgainIn returns the wait asked for in a Reddit error message, or 0 if it
+// asks for none.
+func tryAgainIn(msg string) time.Duration {
+	match := tryAgainPattern.FindStringSubmatch(strings.ToLower(msg))
+	if match == nil {
+		return 0
+	}
+
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+
+	unit := map[string]time.Duration{
+		"millisecond": time.Millisecond,
+		"second":      time.Second,
+		"minute":      time.Minute,
+		"hour":        time.Hour,
+	}[match[2]]
+	return time.Duration(n) * unit
+}
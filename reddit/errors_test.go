@@ -0,0 +1,101 @@
+package reddit
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	for i, test := range []struct {
+		err    *APIError
+		target error
+		is     bool
+	}{
+		{&APIError{StatusCode: http.StatusServiceUnavailable}, BusyErr, true},
+		{&APIError{StatusCode: http.StatusForbidden}, PermissionDeniedErr, true},
+		{&APIError{StatusCode: http.StatusForbidden}, BusyErr, false},
+		{&APIError{StatusCode: http.StatusOK, Code: "RATELIMIT"}, RateLimitErr, true},
+		{
+			&APIError{
+				StatusCode: http.StatusOK,
+				Code:       "BAD_SR_NAME",
+				Others:     []*APIError{&APIError{Code: "RATELIMIT"}},
+			},
+			RateLimitErr, true,
+		},
+		{&APIError{StatusCode: http.StatusNotFound}, BusyErr, false},
+		{
+			statusError(http.StatusForbidden, http.Header{
+				"Www-Authenticate": []string{
+					`Bearer realm="reddit", error="insufficient_scope"`,
+				},
+			}, nil),
+			InsufficientScopeErr, true,
+		},
+		{&APIError{StatusCode: http.StatusForbidden}, InsufficientScopeErr, false},
+	} {
+		if is := errors.Is(test.err, test.target); is != test.is {
+			t.Errorf("%d: got %v; wanted %v", i, is, test.is)
+		}
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	if msg := (&APIError{StatusCode: 503}).Error(); msg != BusyErr.Error() {
+		t.Errorf("wanted sentinel message for status error; got %s", msg)
+	}
+
+	if msg := (&APIError{StatusCode: 404}).Error(); msg != "bad response code: 404" {
+		t.Errorf("wanted response code message; got %s", msg)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	if err := jsonErrors(nil); err != nil {
+		t.Errorf("wanted no error for empty errors; got %v", err)
+	}
+
+	err := jsonErrors([]interface{}{
+		[]interface{}{
+			"RATELIMIT",
+			"you are doing that too much. try again in 5 minutes.",
+			"ratelimit",
+		},
+		[]interface{}{"THREAD_LOCKED", "that thread is locked", nil},
+	})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("wanted *APIError; got %v", err)
+	}
+
+	if apiErr.Code != "RATELIMIT" || apiErr.Field != "ratelimit" {
+		t.Errorf("first error parsed incorrectly: %+v", apiErr)
+	}
+
+	if apiErr.RetryAfter != 5*time.Minute {
+		t.Errorf("wanted retry after 5 minutes; got %v", apiErr.RetryAfter)
+	}
+
+	if !apiErr.Has("THREAD_LOCKED") || apiErr.Has("SUBREDDIT_NOEXIST") {
+		t.Errorf("further errors parsed incorrectly: %v", apiErr.Others)
+	}
+}
+
+func TestTryAgainIn(t *testing.T) {
+	for i, test := range []struct {
+		msg  string
+		wait time.Duration
+	}{
+		{"you are doing that too much. try again in 9 minutes.", 9 * time.Minute},
+		{"Try again in 1 second.", time.Second},
+		{"try again in 300 milliseconds", 300 * time.Millisecond},
+		{"that thread is locked", 0},
+	} {
+		if wait := tryAgainIn(test.msg); wait != test.wait {
+			t.Errorf("%d: got %v; wanted %v", i, wait, test.wait)
+		}
+	}
+}
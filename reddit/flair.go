@@ -0,0 +1,94 @@
+package reddit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// flairCSVLines is the most lines Reddit accepts in one flair CSV upload.
+const flairCSVLines = 100
+
+// FlairTemplate represents one of the flairs a subreddit offers for its posts
+// or users.
+type FlairTemplate struct {
+	ID       string `json:"id"`
+	Text     string `json:"text"`
+	CSSClass string `json:"css_class"`
+	// TextEditable is true if the text may be changed when the flair is
+	// selected.
+	TextEditable    bool   `json:"text_editable"`
+	BackgroundColor string `json:"background_color"`
+	TextColor       string `json:"text_color"`
+	// ModOnly is true if only moderators may select the flair.
+	ModOnly bool `json:"mod_only"`
+}
+
+// UserFlair is the flair of a user in a subreddit, as set in bulk by
+// SetUserFlairCSV. A user with empty Text and CSSClass has their flair cleared.
+type UserFlair struct {
+	User     string
+	Text     string
+	CSSClass string
+}
+
+// flairCSVResult is Reddit's report on one line of a flair CSV upload.
+type flairCSVResult struct {
+	OK     bool              `json:"ok"`
+	Status string            `json:"status"`
+	Errors map[string]string `json:"errors"`
+}
+
+// flairCSV encodes user flairs in the CSV format Reddit expects, one
+// "user,text,css_class" line per user.
+func flairCSV(flairs []UserFlair) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, f := range flairs {
+		if err := w.Write([]string{f.User, f.Text, f.CSSClass}); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// flairCSVErrors returns an *APIError describing every line of a flair CSV
+// upload Reddit rejected, or nil if it accepted them all.
+func flairCSVErrors(flairs []UserFlair, results []flairCSVResult) error {
+	var result *APIError
+	for i, r := range results {
+		if r.OK {
+			continue
+		}
+
+		e := &APIError{StatusCode: http.StatusOK, Message: r.Status}
+		if i < len(flairs) {
+			e.Message = fmt.Sprintf("%s: %s", flairs[i].User, r.Status)
+		}
+		fields := make([]string, 0, len(r.Errors))
+		for field := range r.Errors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			e.Message = fmt.Sprintf("%s; %s", e.Message, r.Errors[field])
+		}
+		if len(fields) > 0 {
+			e.Field = fields[0]
+		}
+
+		if result == nil {
+			result = e
+		} else {
+			result.Others = append(result.Others, e)
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package reddit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestFlairTemplates(t *testing.T) {
+	r := &mockReaper{json: `[
+		{"id": "t1", "text": "Solved", "css_class": "solved", "text_editable": false, "mod_only": true},
+		{"id": "t2", "text": "Question", "text_editable": true, "background_color": "#ffffff"}
+	]`}
+	a := newAccount(r)
+
+	templates, err := a.LinkFlairTemplates("self")
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+
+	if r.path != "/r/self/api/link_flair_v2" {
+		t.Errorf("wrong path: %s", r.path)
+	}
+
+	if diff := pretty.Compare(templates, []*FlairTemplate{
+		{ID: "t1", Text: "Solved", CSSClass: "solved", ModOnly: true},
+		{ID: "t2", Text: "Question", TextEditable: true, BackgroundColor: "#ffffff"},
+	}); diff != "" {
+		t.Errorf("templates incorrect; diff: %s", diff)
+	}
+
+	if _, err := a.UserFlairTemplates("self"); err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+
+	if r.path != "/r/self/api/user_flair_v2" {
+		t.Errorf("wrong path: %s", r.path)
+	}
+}
+
+func TestSelectFlair(t *testing.T) {
+	r := &mockReaper{}
+	a := newAccount(r)
+
+	if err := a.SelectFlair("t3_post", "t1", "Solved!"); err != nil {
+		t.Fatalf("failed to select flair: %v", err)
+	}
+
+	if diff := pretty.Compare(r.values, map[string]string{
+		"link":              "t3_post",
+		"flair_template_id": "t1",
+		"text":              "Solved!",
+	}); r.path != "/api/selectflair" || diff != "" {
+		t.Errorf("wrong request to %s; diff: %s", r.path, diff)
+	}
+
+	if err := a.SetUserFlair("self", "user", "t2", ""); err != nil {
+		t.Fatalf("failed to set user flair: %v", err)
+	}
+
+	if diff := pretty.Compare(r.values, map[string]string{
+		"name":              "user",
+		"flair_template_id": "t2",
+		"text":              "",
+	}); r.path != "/r/self/api/selectflair" || diff != "" {
+		t.Errorf("wrong request to %s; diff: %s", r.path, diff)
+	}
+}
+
+func TestSetUserFlairCSV(t *testing.T) {
+	r := &mockReaper{json: `[
+		{"ok": true, "status": "added flair for user a"},
+		{"ok": false, "status": "skipped", "errors": {"user": "unable to resolve user"}}
+	]`}
+	m := newModerator(r)
+
+	err := m.SetUserFlairCSV("self", []UserFlair{
+		{User: "a", Text: "Helper, first class", CSSClass: "helper"},
+		{User: "b"},
+	})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("wanted *APIError; got %v", err)
+	}
+
+	if apiErr.Field != "user" || !strings.HasPrefix(apiErr.Message, "b: skipped") ||
+		len(apiErr.Others) != 0 {
+		t.Errorf("wrong error: %+v", apiErr)
+	}
+
+	if r.method != "POST" || r.path != "/r/self/api/flaircsv" {
+		t.Errorf("wrong request: %s %s", r.method, r.path)
+	}
+
+	if got, want := r.values["flair_csv"],
+		"a,\"Helper, first class\",helper\nb,,\n"; got != want {
+		t.Errorf("got csv %q; wanted %q", got, want)
+	}
+
+	var flairs []UserFlair
+	for i := 0; i < 150; i++ {
+		flairs = append(flairs, UserFlair{User: fmt.Sprintf("u%d", i)})
+	}
+
+	r.json = `[]`
+	if err := m.SetUserFlairCSV("self", flairs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if lines := strings.Count(r.values["flair_csv"], "\n"); lines != 50 {
+		t.Errorf("wanted the last batch to have 50 lines; got %d", lines)
+	}
+}
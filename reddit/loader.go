@@ -1,23 +1,143 @@
 package reddit
 
 import (
-	"bytes"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/turnage/redditproto"
+	"gopkg.in/yaml.v3"
 )
 
-// load loads the user agent and App config from an AgentFile (legacy graw 0.3.0
-// file format).
-func load(filename string) (string, App, error) {
-	agentPB, err := loadAgentFile(filename)
-	return agentPB.GetUserAgent(), App{
-		ID:       agentPB.GetClientId(),
-		Secret:   agentPB.GetClientSecret(),
-		Username: agentPB.GetUsername(),
-		Password: agentPB.GetPassword(),
-	}, err
+// agentConfig is the contents of an agent file in YAML or JSON.
+type agentConfig struct {
+	UserAgent    string   `json:"user_agent" yaml:"user_agent"`
+	ClientID     string   `json:"client_id" yaml:"client_id"`
+	ClientSecret string   `json:"client_secret" yaml:"client_secret"`
+	Username     string   `json:"username" yaml:"username"`
+	Password     string   `json:"password" yaml:"password"`
+	RefreshToken string   `json:"refresh_token" yaml:"refresh_token"`
+	Scopes       []string `json:"scopes" yaml:"scopes"`
+	TokenURL     string   `json:"token_url" yaml:"token_url"`
+	// RetryAttempts is the MaxAttempts of the bot's retry policy. If 0,
+	// the default policy is used.
+	RetryAttempts int `json:"retry_attempts" yaml:"retry_attempts"`
+}
+
+func (a agentConfig) botConfig() BotConfig {
+	c := BotConfig{
+		Agent: a.UserAgent,
+		App: App{
+			ID:           a.ClientID,
+			Secret:       a.ClientSecret,
+			Username:     a.Username,
+			Password:     a.Password,
+			RefreshToken: a.RefreshToken,
+			Scopes:       a.Scopes,
+			TokenURL:     a.TokenURL,
+		},
+	}
+	if a.RetryAttempts != 0 {
+		c.Retry = DefaultRetryPolicy
+		c.Retry.MaxAttempts = a.RetryAttempts
+	}
+	return c
+}
+
+// Environment variables read by NewBotFromEnv.
+const (
+	EnvUserAgent    = "GRAW_USER_AGENT"
+	EnvClientID     = "GRAW_CLIENT_ID"
+	EnvClientSecret = "GRAW_CLIENT_SECRET"
+	EnvUsername     = "GRAW_USERNAME"
+	EnvPassword     = "GRAW_PASSWORD"
+	EnvRefreshToken = "GRAW_REFRESH_TOKEN"
+	// EnvScopes is a comma separated list of OAuth2 scopes.
+	EnvScopes = "GRAW_SCOPES"
+	// EnvRetryAttempts is the maximum number of times a request is
+	// attempted; 1 disables retries.
+	EnvRetryAttempts = "GRAW_RETRY_ATTEMPTS"
+)
+
+// refreshTokenField, tokenURLField and retryAttemptsField match fields of an
+// agent file which are not part of the legacy protobuf message, so they are
+// read separately.
+var (
+	refreshTokenField = regexp.MustCompile(
+		`(?m)^[ \t]*refresh_token[ \t]*:[ \t]*("(?:[^"\\]|\\.)*")[ \t]*$`,
+	)
+	tokenURLField = regexp.MustCompile(
+		`(?m)^[ \t]*token_url[ \t]*:[ \t]*("(?:[^"\\]|\\.)*")[ \t]*$`,
+	)
+	retryAttemptsField = regexp.MustCompile(
+		`(?m)^[ \t]*retry_attempts[ \t]*:[ \t]*(\d+)[ \t]*$`,
+	)
+)
+
+// load loads the bot config from an agent file. Files ending in .yaml, .yml or
+// .json are read in those formats, and any other file in the legacy graw 0.3.0
+// file format.
+func load(filename string) (BotConfig, error) {
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return BotConfig{}, err
+	}
+
+	var cfg agentConfig
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		err := yaml.Unmarshal(buf, &cfg)
+		return cfg.botConfig(), err
+	case ".json":
+		err := json.Unmarshal(buf, &cfg)
+		return cfg.botConfig(), err
+	}
+
+	agentPB, cfg, err := parseAgentFile(string(buf))
+	cfg.UserAgent = agentPB.GetUserAgent()
+	cfg.ClientID = agentPB.GetClientId()
+	cfg.ClientSecret = agentPB.GetClientSecret()
+	cfg.Username = agentPB.GetUsername()
+	cfg.Password = agentPB.GetPassword()
+	return cfg.botConfig(), err
+}
+
+// loadEnv loads the bot config from the environment variables read by
+// NewBotFromEnv.
+func loadEnv(getenv func(string) string) (BotConfig, error) {
+	agent := getenv(EnvUserAgent)
+	if agent == "" {
+		return BotConfig{}, fmt.Errorf("%s is not set", EnvUserAgent)
+	}
+
+	cfg := agentConfig{
+		UserAgent:    agent,
+		ClientID:     getenv(EnvClientID),
+		ClientSecret: getenv(EnvClientSecret),
+		Username:     getenv(EnvUsername),
+		Password:     getenv(EnvPassword),
+		RefreshToken: getenv(EnvRefreshToken),
+	}
+	for _, scope := range strings.Split(getenv(EnvScopes), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			cfg.Scopes = append(cfg.Scopes, scope)
+		}
+	}
+
+	if attempts := getenv(EnvRetryAttempts); attempts != "" {
+		n, err := strconv.Atoi(attempts)
+		if err != nil {
+			return BotConfig{}, fmt.Errorf("%s: %v", EnvRetryAttempts, err)
+		}
+		cfg.RetryAttempts = n
+	}
+
+	return cfg.botConfig(), nil
 }
 
 // loadAgentFile reads a user agent from a protobuffer file and returns it.
@@ -27,6 +147,51 @@ func loadAgentFile(filename string) (*redditproto.UserAgent, error) {
 		return nil, err
 	}
 
+	agent, _, err := parseAgentFile(string(buf))
+	return agent, err
+}
+
+// parseAgentFile parses the text of an agent file into the user agent message
+// and the fields which are not part of it, if the file has them.
+func parseAgentFile(text string) (*redditproto.UserAgent, agentConfig, error) {
+	var cfg agentConfig
+	for _, field := range []struct {
+		re  *regexp.Regexp
+		dst *string
+	}{
+		{refreshTokenField, &cfg.RefreshToken},
+		{tokenURLField, &cfg.TokenURL},
+	} {
+		match := field.re.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+
+		unquoted, err := strconv.Unquote(match[1])
+		if err != nil {
+			return nil, cfg, err
+		}
+		*field.dst = unquoted
+		text = field.re.ReplaceAllString(text, "")
+	}
+
+	if match := retryAttemptsField.FindStringSubmatch(text); match != nil {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, cfg, err
+		}
+		cfg.RetryAttempts = n
+		text = retryAttemptsField.ReplaceAllString(text, "")
+	}
+
 	agent := &redditproto.UserAgent{}
-	return agent, proto.UnmarshalText(bytes.NewBuffer(buf).String(), agent)
+	err := proto.UnmarshalText(text, agent)
+
+	// The legacy message requires a username and password, which agents
+	// authorizing with a refresh token do not have.
+	if _, ok := err.(*proto.RequiredNotSetError); ok && cfg.RefreshToken != "" {
+		err = nil
+	}
+
+	return agent, cfg, err
 }
@@ -2,9 +2,12 @@ package reddit
 
 import (
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/kylelemons/godebug/pretty"
 	"github.com/turnage/redditproto"
 )
 
@@ -42,3 +45,157 @@ func TestLoad(t *testing.T) {
 		t.Errorf("got %v; wanted %v", actual, expected)
 	}
 }
+
+func TestLoadRefreshToken(t *testing.T) {
+	testFile, err := ioutil.TempFile("", "user_agent")
+	if err != nil {
+		t.Fatalf("failed to make test input file: %v", err)
+	}
+	defer os.Remove(testFile.Name())
+
+	if _, err := testFile.WriteString(`
+		user_agent: "test"
+		client_id: "id"
+		client_secret: ""
+		refresh_token: "refresh\"token"
+		token_url: "http://localhost/token"
+		retry_attempts: 1
+	`); err != nil {
+		t.Fatalf("failed to write test input file: %v", err)
+	}
+
+	c, err := load(testFile.Name())
+	if err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	if c.Agent != "test" || c.App.ID != "id" ||
+		c.App.RefreshToken != `refresh"token` ||
+		c.App.TokenURL != "http://localhost/token" || c.Retry.MaxAttempts != 1 {
+		t.Errorf("loaded incorrectly: %+v", c)
+	}
+}
+
+func TestLoadFormats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "agents")
+	if err != nil {
+		t.Fatalf("failed to make test directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	expected := App{
+		ID:           "id",
+		Secret:       "secret",
+		RefreshToken: "refresh",
+		Scopes:       []string{"read", "vote"},
+	}
+	retry := DefaultRetryPolicy
+	retry.MaxAttempts = 6
+
+	for i, test := range []struct {
+		name string
+		text string
+	}{
+		{"agent.yaml", `
+user_agent: test
+client_id: id
+client_secret: secret
+refresh_token: refresh
+scopes: [read, vote]
+retry_attempts: 6
+`},
+		{"agent.yml", `
+user_agent: "test"
+client_id: "id"
+client_secret: "secret"
+refresh_token: "refresh"
+scopes:
+  - read
+  - vote
+retry_attempts: 6
+`},
+		{"agent.json", `{
+	"user_agent": "test",
+	"client_id": "id",
+	"client_secret": "secret",
+	"refresh_token": "refresh",
+	"scopes": ["read", "vote"],
+	"retry_attempts": 6
+}`},
+	} {
+		filename := filepath.Join(dir, test.name)
+		if err := ioutil.WriteFile(filename, []byte(test.text), 0600); err != nil {
+			t.Fatalf("failed to write test input file: %v", err)
+		}
+
+		c, err := load(filename)
+		if err != nil {
+			t.Errorf("%d: failed: %v", i, err)
+			continue
+		}
+
+		if c.Agent != "test" {
+			t.Errorf("%d: got agent %s; wanted test", i, c.Agent)
+		}
+
+		if diff := pretty.Compare(c.App, expected); diff != "" {
+			t.Errorf("%d: app loaded incorrectly; diff: %s", i, diff)
+		}
+
+		if c.Retry.MaxAttempts != retry.MaxAttempts ||
+			c.Retry.BaseDelay != retry.BaseDelay {
+			t.Errorf("%d: got retry policy %+v; wanted %+v", i, c.Retry, retry)
+		}
+	}
+}
+
+func TestLoadEnv(t *testing.T) {
+	env := map[string]string{
+		EnvUserAgent:    "test",
+		EnvClientID:     "id",
+		EnvClientSecret: "secret",
+		EnvUsername:     "user",
+		EnvPassword:     "pass",
+		EnvScopes:       "read, modposts,",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	c, err := loadEnv(getenv)
+	if err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	expected := App{
+		ID:       "id",
+		Secret:   "secret",
+		Username: "user",
+		Password: "pass",
+		Scopes:   []string{"read", "modposts"},
+	}
+	if c.Agent != "test" {
+		t.Errorf("got agent %s; wanted test", c.Agent)
+	}
+
+	if diff := pretty.Compare(c.App, expected); diff != "" {
+		t.Errorf("app loaded incorrectly; diff: %s", diff)
+	}
+
+	if c.Retry.MaxAttempts != 0 {
+		t.Errorf("wanted retry policy unset; got %+v", c.Retry)
+	}
+
+	env[EnvRetryAttempts] = "1"
+	if c, err := loadEnv(getenv); err != nil || c.Retry.MaxAttempts != 1 {
+		t.Errorf("wanted retries disabled; got %+v, %v", c.Retry, err)
+	}
+
+	env[EnvRetryAttempts] = "many"
+	if _, err := loadEnv(getenv); err == nil {
+		t.Errorf("wanted error loading a malformed retry attempts count")
+	}
+
+	delete(env, EnvUserAgent)
+	if _, err := loadEnv(getenv); err == nil {
+		t.Errorf("wanted error loading without a user agent")
+	}
+}
@@ -1,9 +1,72 @@
 package reddit
 
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// moreChildrenLimit is the maximum number of comments Reddit will expand in
+// a single /api/morechildren request.
+const moreChildrenLimit = 100
+
+// infoLimit is the maximum number of things Reddit will describe in a single
+// /api/info request.
+const infoLimit = 100
+
 // Lurker defines browsing behavior.
 type Lurker interface {
 	// Thread returns a Reddit post with a fully parsed comment tree.
 	Thread(permalink string) (*Post, error)
+	// ThreadContext is like Thread, but binds its request, including the
+	// wait for the rate limit, to the given context.
+	ThreadContext(ctx context.Context, permalink string) (*Post, error)
+	// ThreadWithMore returns a Reddit post like Thread, and then resolves
+	// the "load more comments" stubs in its comment tree through
+	// /api/morechildren, splicing the comments into the Replies of their
+	// parents. At most maxRequests requests are spent expanding the tree;
+	// if maxRequests is 0 or less there is no limit. Stubs that could not
+	// be resolved within the limit are left in place holding the names of
+	// the comments they still stand for.
+	ThreadWithMore(permalink string, maxRequests int) (*Post, error)
+	// ThreadWithMoreContext is like ThreadWithMore, but binds its
+	// requests, including the waits for the rate limit, to the given
+	// context.
+	ThreadWithMoreContext(
+		ctx context.Context,
+		permalink string,
+		maxRequests int,
+	) (*Post, error)
+
+	// Subreddit returns a subreddit's settings and statistics, given its
+	// display name, e.g. "golang".
+	Subreddit(name string) (*Subreddit, error)
+	SubredditContext(ctx context.Context, name string) (*Subreddit, error)
+	// SubredditRules returns a subreddit's rules, given its display name,
+	// in the order the subreddit lists them.
+	SubredditRules(name string) ([]*Rule, error)
+	SubredditRulesContext(ctx context.Context, name string) ([]*Rule, error)
+
+	// Redditor returns a user's account, given their username. Suspended
+	// accounts are returned with IsSuspended set; accounts which are
+	// deleted, or hidden from the requester because they are
+	// shadowbanned, fail with RedditorDoesNotExistErr.
+	Redditor(name string) (*Redditor, error)
+	RedditorContext(ctx context.Context, name string) (*Redditor, error)
+
+	// GetInfo returns the current state of posts, comments and subreddits,
+	// given their full names, e.g. a comment's ParentID. Requests for more
+	// than 100 things are split into one request per 100. Things which do
+	// not exist, or cannot be seen, are left out of the harvest.
+	GetInfo(fullnames ...string) (Harvest, error)
+	GetInfoContext(ctx context.Context, fullnames ...string) (Harvest, error)
+	// GetInfoByURL returns the posts which link to a URL. Every page of
+	// them is read, 100 posts per request.
+	GetInfoByURL(url string) (Harvest, error)
+	GetInfoByURLContext(ctx context.Context, url string) (Harvest, error)
 }
 
 type lurker struct {
@@ -15,7 +78,15 @@ func newLurker(r reaper) Lurker {
 }
 
 func (s *lurker) Thread(permalink string) (*Post, error) {
+	return s.ThreadContext(context.Background(), permalink)
+}
+
+func (s *lurker) ThreadContext(
+	ctx context.Context,
+	permalink string,
+) (*Post, error) {
 	harvest, err := s.r.reap(
+		ctx,
 		permalink+".json",
 		map[string]string{"raw_json": "1"},
 	)
@@ -29,3 +100,340 @@ func (s *lurker) Thread(permalink string) (*Post, error) {
 
 	return harvest.Posts[0], nil
 }
+
+func (s *lurker) Subreddit(name string) (*Subreddit, error) {
+	return s.SubredditContext(context.Background(), name)
+}
+
+func (s *lurker) SubredditContext(
+	ctx context.Context,
+	name string,
+) (*Subreddit, error) {
+	harvest, err := s.r.reap(
+		ctx,
+		"/r/"+name+"/about",
+		map[string]string{"raw_json": "1"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reddit answers requests for subreddits which do not exist with a
+	// listing of search results.
+	if len(harvest.Subreddits) != 1 ||
+		!strings.EqualFold(harvest.Subreddits[0].DisplayName, name) {
+		return nil, SubredditDoesNotExistErr
+	}
+
+	return harvest.Subreddits[0], nil
+}
+
+func (s *lurker) SubredditRules(name string) ([]*Rule, error) {
+	return s.SubredditRulesContext(context.Background(), name)
+}
+
+func (s *lurker) SubredditRulesContext(
+	ctx context.Context,
+	name string,
+) ([]*Rule, error) {
+	var resp struct {
+		Rules []map[string]interface{} `json:"rules"`
+	}
+	if err := s.r.reap_json(
+		ctx,
+		"/r/"+name+"/about/rules",
+		map[string]string{"raw_json": "1"},
+		&resp,
+	); err != nil {
+		return nil, err
+	}
+
+	rules := []*Rule{}
+	for _, data := range resp.Rules {
+		r, err := parseRule(data)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+	return rules, nil
+}
+
+func (s *lurker) Redditor(name string) (*Redditor, error) {
+	return s.RedditorContext(context.Background(), name)
+}
+
+func (s *lurker) RedditorContext(
+	ctx context.Context,
+	name string,
+) (*Redditor, error) {
+	harvest, err := s.r.reap(
+		ctx,
+		"/user/"+name+"/about",
+		map[string]string{"raw_json": "1"},
+	)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, RedditorDoesNotExistErr
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(harvest.Redditors) != 1 {
+		return nil, RedditorDoesNotExistErr
+	}
+
+	return harvest.Redditors[0], nil
+}
+
+func (s *lurker) GetInfo(fullnames ...string) (Harvest, error) {
+	return s.GetInfoContext(context.Background(), fullnames...)
+}
+
+func (s *lurker) GetInfoContext(
+	ctx context.Context,
+	fullnames ...string,
+) (Harvest, error) {
+	info := Harvest{}
+	for start := 0; start < len(fullnames); start += infoLimit {
+		end := start + infoLimit
+		if end > len(fullnames) {
+			end = len(fullnames)
+		}
+
+		harvest, err := s.r.reap(
+			ctx, "/api/info", map[string]string{
+				"raw_json": "1",
+				"limit":    strconv.Itoa(infoLimit),
+				"id":       strings.Join(fullnames[start:end], ","),
+			},
+		)
+		if err != nil {
+			return Harvest{}, err
+		}
+
+		info.Comments = append(info.Comments, harvest.Comments...)
+		info.Posts = append(info.Posts, harvest.Posts...)
+		info.Subreddits = append(info.Subreddits, harvest.Subreddits...)
+	}
+
+	return info, nil
+}
+
+func (s *lurker) GetInfoByURL(url string) (Harvest, error) {
+	return s.GetInfoByURLContext(context.Background(), url)
+}
+
+func (s *lurker) GetInfoByURLContext(
+	ctx context.Context,
+	url string,
+) (Harvest, error) {
+	info := Harvest{}
+	after := ""
+	for {
+		harvest, err := s.r.reap(
+			ctx, "/api/info", map[string]string{
+				"raw_json": "1",
+				"limit":    strconv.Itoa(infoLimit),
+				"url":      url,
+				"after":    after,
+			},
+		)
+		if err != nil {
+			return Harvest{}, err
+		}
+
+		info.Posts = append(info.Posts, harvest.Posts...)
+
+		// The harvest does not carry the listing's after, but it is
+		// the name of the last post of a full page.
+		if len(harvest.Posts) < infoLimit {
+			return info, nil
+		}
+
+		last := harvest.Posts[len(harvest.Posts)-1].Name
+		if last == after {
+			return info, nil
+		}
+		after = last
+	}
+}
+
+func (s *lurker) ThreadWithMore(permalink string, maxRequests int) (*Post, error) {
+	return s.ThreadWithMoreContext(
+		context.Background(),
+		permalink,
+		maxRequests,
+	)
+}
+
+func (s *lurker) ThreadWithMoreContext(
+	ctx context.Context,
+	permalink string,
+	maxRequests int,
+) (*Post, error) {
+	post, err := s.ThreadContext(ctx, permalink)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := newCommentTree(post)
+	for i := 0; maxRequests <= 0 || i < maxRequests; i++ {
+		b := tree.nextBatch(moreChildrenLimit)
+		if len(b.names) == 0 {
+			break
+		}
+
+		harvest, err := s.r.reap(
+			ctx,
+			"/api/morechildren",
+			map[string]string{
+				"api_type": "json",
+				"link_id":  post.Name,
+				"children": strings.Join(b.names, ","),
+				"raw_json": "1",
+			},
+		)
+		if err != nil {
+			return post, err
+		}
+
+		tree.graft(b, harvest.Comments, harvest.Mores)
+	}
+
+	return post, nil
+}
+
+// commentTree indexes the comment tree of a post so comments and more stubs
+// returned by /api/morechildren can be spliced into it by their parent ids.
+type commentTree struct {
+	post *Post
+	// comments maps the names of all comments in the tree to the comment.
+	comments map[string]*Comment
+	// stubs is the queue of more stubs in the tree with children left to
+	// resolve.
+	stubs []*More
+}
+
+func newCommentTree(post *Post) *commentTree {
+	t := &commentTree{
+		post:     post,
+		comments: make(map[string]*Comment),
+	}
+	t.queue(post.More)
+	t.index(post.Replies)
+	return t
+}
+
+// index adds comments and their reply trees to the tree's index.
+func (t *commentTree) index(comments []*Comment) {
+	for _, c := range comments {
+		t.comments[c.Name] = c
+		t.queue(c.More)
+		t.index(c.Replies)
+	}
+}
+
+// queue queues a more stub for resolution. Stubs without children (such as
+// "continue this thread" links) cannot be resolved by /api/morechildren.
+func (t *commentTree) queue(m *More) {
+	if m != nil && len(m.Children) > 0 {
+		t.stubs = append(t.stubs, m)
+	}
+}
+
+// batch is a set of comment names taken from the front of the stub queue to
+// be resolved in one request.
+type batch struct {
+	names []string
+	// counts are the numbers of names taken from each stub, in queue
+	// order.
+	counts []int
+}
+
+// nextBatch takes up to n comment names from the queued stubs. The stubs are
+// left unchanged until the batch is grafted, so that a failed request leaves
+// the names it did not resolve in the tree.
+func (t *commentTree) nextBatch(n int) batch {
+	var b batch
+	for _, m := range t.stubs {
+		if len(b.names) >= n {
+			break
+		}
+
+		take := n - len(b.names)
+		if take > len(m.Children) {
+			take = len(m.Children)
+		}
+		b.names = append(b.names, m.Children[:take]...)
+		b.counts = append(b.counts, take)
+	}
+	return b
+}
+
+// graft removes the names of a resolved batch from their stubs, detaching
+// stubs which are emptied from their parents, then splices the comments and
+// more stubs resolved for it into the tree under their parents. Things whose
+// parents are not in the tree are dropped.
+func (t *commentTree) graft(b batch, comments []*Comment, mores []*More) {
+	for _, count := range b.counts {
+		m := t.stubs[0]
+		m.Children = m.Children[count:]
+		if len(m.Children) > 0 {
+			break
+		}
+
+		t.stubs = t.stubs[1:]
+		if more := t.moreOf(m.ParentID); more != nil && *more == m {
+			*more = nil
+		}
+	}
+
+	for _, c := range comments {
+		if c.ParentID == t.post.Name {
+			t.post.Replies = append(t.post.Replies, c)
+		} else if parent, ok := t.comments[c.ParentID]; ok {
+			parent.Replies = append(parent.Replies, c)
+		} else {
+			continue
+		}
+		t.index([]*Comment{c})
+	}
+
+	for _, m := range mores {
+		more := t.moreOf(m.ParentID)
+		if more == nil {
+			continue
+		}
+
+		// A parent has at most one stub, so a new stub for a parent
+		// whose stub is still queued is folded into it.
+		if *more != nil && len((*more).Children) > 0 {
+			(*more).Children = append((*more).Children, m.Children...)
+			(*more).Count += m.Count
+			continue
+		}
+
+		*more = m
+		t.queue(m)
+	}
+}
+
+// moreOf returns the location of the more stub of the named parent in the
+// tree, or nil if the parent is not in the tree.
+func (t *commentTree) moreOf(parentID string) **More {
+	if parentID == t.post.Name {
+		return &t.post.More
+	}
+
+	if parent, ok := t.comments[parentID]; ok {
+		return &parent.More
+	}
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package reddit
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
@@ -33,3 +36,325 @@ func TestThreadReturnsEmpty(t *testing.T) {
 		t.Errorf("err unexpected; wanted DoesNotExistErr; got %v", err)
 	}
 }
+
+func TestThreadWithMore(t *testing.T) {
+	post := &Post{
+		Name: "t3_post",
+		Replies: []*Comment{
+			&Comment{
+				Name:     "t1_a",
+				ParentID: "t3_post",
+				More: &More{
+					ParentID: "t1_a",
+					Children: []string{"c"},
+				},
+			},
+		},
+		More: &More{
+			ParentID: "t3_post",
+			Children: []string{"b"},
+		},
+	}
+	r := &mockReaper{
+		queue: []Harvest{
+			Harvest{Posts: []*Post{post}},
+			Harvest{
+				Comments: []*Comment{
+					&Comment{Name: "t1_b", ParentID: "t3_post"},
+					&Comment{Name: "t1_c", ParentID: "t1_a"},
+					&Comment{Name: "t1_d", ParentID: "t1_c"},
+				},
+				Mores: []*More{
+					&More{ParentID: "t1_d", Children: []string{"e"}},
+				},
+			},
+			Harvest{
+				Comments: []*Comment{
+					&Comment{Name: "t1_e", ParentID: "t1_d"},
+				},
+			},
+		},
+	}
+	s := newLurker(r)
+
+	actual, err := s.ThreadWithMore("", 0)
+	if err != nil {
+		t.Fatalf("error pulling thread: %v", err)
+	}
+
+	if r.reaps != 3 {
+		t.Errorf("wanted 3 requests; got %d", r.reaps)
+	}
+
+	if r.path != "/api/morechildren" || r.values["children"] != "e" ||
+		r.values["link_id"] != "t3_post" {
+		t.Errorf("last request incorrect: %s %v", r.path, r.values)
+	}
+
+	expected := &Post{
+		Name: "t3_post",
+		Replies: []*Comment{
+			&Comment{
+				Name:     "t1_a",
+				ParentID: "t3_post",
+				Replies: []*Comment{
+					&Comment{
+						Name:     "t1_c",
+						ParentID: "t1_a",
+						Replies: []*Comment{
+							&Comment{
+								Name:     "t1_d",
+								ParentID: "t1_c",
+								Replies: []*Comment{
+									&Comment{
+										Name:     "t1_e",
+										ParentID: "t1_d",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			&Comment{Name: "t1_b", ParentID: "t3_post"},
+		},
+	}
+	if diff := pretty.Compare(actual, expected); diff != "" {
+		t.Errorf("thread incorrect; diff: %s", diff)
+	}
+}
+
+func TestThreadWithMoreLimit(t *testing.T) {
+	children := make([]string, moreChildrenLimit+1)
+	for i := range children {
+		children[i] = "x"
+	}
+	post := &Post{
+		Name: "t3_post",
+		More: &More{ParentID: "t3_post", Children: children},
+	}
+	r := &mockReaper{queue: []Harvest{Harvest{Posts: []*Post{post}}}}
+	s := newLurker(r)
+
+	actual, err := s.ThreadWithMore("", 1)
+	if err != nil {
+		t.Fatalf("error pulling thread: %v", err)
+	}
+
+	if r.reaps != 2 {
+		t.Errorf("wanted 2 requests; got %d", r.reaps)
+	}
+
+	if actual.More == nil || len(actual.More.Children) != 1 {
+		t.Errorf("wanted unresolved stub left in place; got %v", actual.More)
+	}
+}
+
+func TestCommentTreeBatch(t *testing.T) {
+	post := &Post{
+		Name: "t3_post",
+		Replies: []*Comment{
+			&Comment{
+				Name:     "t1_a",
+				ParentID: "t3_post",
+				More:     &More{ParentID: "t1_a", Children: []string{"c", "d"}},
+			},
+		},
+		More: &More{ParentID: "t3_post", Children: []string{"b"}},
+	}
+	tree := newCommentTree(post)
+
+	b := tree.nextBatch(2)
+	if diff := pretty.Compare(b.names, []string{"b", "c"}); diff != "" {
+		t.Errorf("batch incorrect; diff: %s", diff)
+	}
+
+	// Until the batch is grafted, as when its request fails, the stubs
+	// must still hold every name.
+	if post.More == nil || len(post.More.Children) != 1 ||
+		len(post.Replies[0].More.Children) != 2 {
+		t.Fatalf("stubs changed before graft: %v, %v", post.More, post.Replies[0].More)
+	}
+
+	tree.graft(b, []*Comment{
+		&Comment{Name: "t1_b", ParentID: "t3_post"},
+		&Comment{Name: "t1_c", ParentID: "t1_a"},
+	}, nil)
+
+	if post.More != nil {
+		t.Errorf("wanted emptied stub detached; got %v", post.More)
+	}
+
+	if more := post.Replies[0].More; more == nil ||
+		len(more.Children) != 1 || more.Children[0] != "d" {
+		t.Errorf("wanted partly resolved stub trimmed; got %v", more)
+	}
+
+	if b := tree.nextBatch(2); len(b.names) != 1 || b.names[0] != "d" {
+		t.Errorf("wanted the remaining name next; got %v", b.names)
+	}
+}
+
+func TestSubreddit(t *testing.T) {
+	r := reaperWhich(
+		Harvest{
+			Subreddits: []*Subreddit{
+				&Subreddit{DisplayName: "golang", SubmissionType: "self"},
+			},
+		},
+		nil,
+	)
+	s := newLurker(r)
+
+	sub, err := s.Subreddit("Golang")
+	if err != nil {
+		t.Fatalf("error getting subreddit: %v", err)
+	}
+
+	if r.path != "/r/Golang/about" || sub.SubmissionType != "self" {
+		t.Errorf("got %+v from %s", sub, r.path)
+	}
+
+	// Reddit searches for subreddits which do not exist.
+	r.h = Harvest{
+		Subreddits: []*Subreddit{&Subreddit{DisplayName: "golangs"}},
+	}
+	if _, err := s.Subreddit("golang"); err != SubredditDoesNotExistErr {
+		t.Errorf("err unexpected; wanted DoesNotExistErr; got %v", err)
+	}
+}
+
+func TestSubredditRules(t *testing.T) {
+	r := &mockReaper{json: `{
+		"rules": [
+			{"kind": "link", "short_name": "No memes", "violation_reason": "Meme", "priority": 1, "created_utc": 1500000001.0},
+			{"kind": "all", "short_name": "Be nice", "description": "Really.", "priority": 0, "created_utc": 1500000000.0}
+		],
+		"site_rules": ["Spam"]
+	}`}
+	s := newLurker(r)
+
+	rules, err := s.SubredditRules("golang")
+	if err != nil {
+		t.Fatalf("error getting rules: %v", err)
+	}
+
+	if r.path != "/r/golang/about/rules" {
+		t.Errorf("wrong path: %s", r.path)
+	}
+
+	expected := []*Rule{
+		&Rule{
+			Kind:        "all",
+			ShortName:   "Be nice",
+			Description: "Really.",
+			CreatedUTC:  1500000000,
+		},
+		&Rule{
+			Kind:            "link",
+			ShortName:       "No memes",
+			ViolationReason: "Meme",
+			Priority:        1,
+			CreatedUTC:      1500000001,
+		},
+	}
+	if diff := pretty.Compare(rules, expected); diff != "" {
+		t.Errorf("rules incorrect; diff: %s", diff)
+	}
+}
+
+func TestRedditor(t *testing.T) {
+	r := reaperWhich(
+		Harvest{
+			Redditors: []*Redditor{
+				&Redditor{Name: "gopher", IsSuspended: true},
+			},
+		},
+		nil,
+	)
+	s := newLurker(r)
+
+	user, err := s.Redditor("gopher")
+	if err != nil {
+		t.Fatalf("error getting redditor: %v", err)
+	}
+
+	if r.path != "/user/gopher/about" || !user.IsSuspended {
+		t.Errorf("got %+v from %s", user, r.path)
+	}
+
+	r.err = &APIError{StatusCode: http.StatusNotFound}
+	if _, err := s.Redditor("gopher"); err != RedditorDoesNotExistErr {
+		t.Errorf("err unexpected; wanted DoesNotExistErr; got %v", err)
+	}
+}
+
+func TestGetInfo(t *testing.T) {
+	r := &mockReaper{
+		queue: []Harvest{
+			Harvest{
+				Posts:    []*Post{&Post{Name: "t3_a", Locked: true}},
+				Comments: []*Comment{&Comment{Name: "t1_b"}},
+			},
+			Harvest{
+				Subreddits: []*Subreddit{&Subreddit{Name: "t5_c"}},
+			},
+		},
+	}
+	s := newLurker(r)
+
+	var fullnames []string
+	for i := 0; i < 150; i++ {
+		fullnames = append(fullnames, fmt.Sprintf("t1_%d", i))
+	}
+
+	info, err := s.GetInfo(fullnames...)
+	if err != nil {
+		t.Fatalf("error getting info: %v", err)
+	}
+
+	if r.reaps != 2 {
+		t.Errorf("wanted 2 requests for 150 things; got %d", r.reaps)
+	}
+
+	if r.path != "/api/info" ||
+		r.values["id"] != strings.Join(fullnames[100:], ",") {
+		t.Errorf("wrong last request to %s: %v", r.path, r.values)
+	}
+
+	if diff := pretty.Compare(info, Harvest{
+		Posts:      []*Post{&Post{Name: "t3_a", Locked: true}},
+		Comments:   []*Comment{&Comment{Name: "t1_b"}},
+		Subreddits: []*Subreddit{&Subreddit{Name: "t5_c"}},
+	}); diff != "" {
+		t.Errorf("info incorrect; diff: %s", diff)
+	}
+
+	var page []*Post
+	for i := 0; i < infoLimit; i++ {
+		page = append(page, &Post{Name: fmt.Sprintf("t3_%d", i)})
+	}
+	r.reaps = 0
+	r.queue = []Harvest{
+		Harvest{Posts: page},
+		Harvest{Posts: []*Post{&Post{Name: "t3_last"}}},
+	}
+
+	info, err = s.GetInfoByURL("https://go.dev")
+	if err != nil {
+		t.Fatalf("error getting info by url: %v", err)
+	}
+
+	if r.reaps != 2 {
+		t.Errorf("wanted 2 requests for 2 pages; got %d", r.reaps)
+	}
+
+	if r.path != "/api/info" || r.values["url"] != "https://go.dev" ||
+		r.values["after"] != "t3_99" {
+		t.Errorf("wrong last request to %s: %v", r.path, r.values)
+	}
+
+	if len(info.Posts) != infoLimit+1 || info.Posts[infoLimit].Name != "t3_last" {
+		t.Errorf("wanted every page of posts; got %d", len(info.Posts))
+	}
+}
@@ -1,15 +1,41 @@
 package reddit
 
 import (
+	"io/ioutil"
 	"net/http"
 )
 
-// mockClient stores the request it receives.
+// mockClient stores the request it receives. The body of the request is read
+// into body, and the stored request has none so it can be compared easily.
 type mockClient struct {
 	request *http.Request
+	body    string
+	// limit, if set, is reported as the client's rate limit budget.
+	limit *RateLimit
 }
 
 func (m *mockClient) Do(r *http.Request) ([]byte, error) {
+	m.body = ""
+	if r.Body != nil {
+		buf, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		m.body = string(buf)
+
+		stripped := *r
+		stripped.Body = nil
+		stripped.GetBody = nil
+		r = &stripped
+	}
+
 	m.request = r
 	return nil, nil
 }
+
+func (m *mockClient) RateLimit() (RateLimit, bool) {
+	if m.limit == nil {
+		return RateLimit{}, false
+	}
+	return *m.limit, true
+}
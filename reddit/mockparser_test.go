@@ -5,17 +5,13 @@ import (
 )
 
 type mockParser struct {
-	comments   []*Comment
-	posts      []*Post
-	messages   []*Message
-	mores      []*More
+	h          Harvest
 	submission Submission
+	err        error
 }
 
-func (m *mockParser) parse(
-	blob json.RawMessage,
-) ([]*Comment, []*Post, []*Message, []*More, error) {
-	return m.comments, m.posts, m.messages, m.mores, nil
+func (m *mockParser) parse(blob json.RawMessage) (Harvest, error) {
+	return m.h, nil
 }
 
 func (m *mockParser) parse_submitted(
@@ -24,10 +20,10 @@ func (m *mockParser) parse_submitted(
 	return m.submission, nil
 }
 
+func (m *mockParser) parse_errors(blob json.RawMessage) error {
+	return m.err
+}
+
 func parserWhich(h Harvest) parser {
-	return &mockParser{
-		comments: h.Comments,
-		posts:    h.Posts,
-		messages: h.Messages,
-	}
+	return &mockParser{h: h}
 }
@@ -1,30 +1,76 @@
 package reddit
 
+import (
+	"context"
+	"encoding/json"
+)
+
 // mockReaper saves the paths it is sent and returns preconfigured results.
 type mockReaper struct {
 	// path is the path received by the most recent Reap or Sow call.
 	path string
+	// values are the values received by the most recent Reap or Sow call.
+	values map[string]string
+	// reaps is the number of Reap calls received.
+	reaps int
 
 	h   Harvest
 	s   Submission
 	err error
+
+	// method is the method received by the most recent sow_json call.
+	method string
+	// json is the response decoded by reap_json and sow_json calls.
+	json string
+
+	// queue, if set, is a sequence of harvests returned by successive Reap
+	// calls instead of h.
+	queue []Harvest
 }
 
-func (m *mockReaper) reap(path string, _ map[string]string) (Harvest, error) {
+func (m *mockReaper) reap(_ context.Context, path string, values map[string]string) (Harvest, error) {
 	m.path = path
+	m.values = values
+	m.reaps++
+	if len(m.queue) > 0 {
+		h := m.queue[0]
+		m.queue = m.queue[1:]
+		return h, m.err
+	}
 	return m.h, m.err
 }
 
-func (m *mockReaper) sow(path string, _ map[string]string) error {
+func (m *mockReaper) sow(_ context.Context, path string, values map[string]string) error {
 	m.path = path
+	m.values = values
 	return m.err
 }
 
-func (m *mockReaper) get_sow(path string, _ map[string]string) (Submission, error) {
+func (m *mockReaper) get_sow(_ context.Context, path string, values map[string]string) (Submission, error) {
 	m.path = path
+	m.values = values
 	return m.s, m.err
 }
 
+func (m *mockReaper) reap_json(_ context.Context, path string, values map[string]string, v interface{}) error {
+	m.path = path
+	m.values = values
+	if m.err != nil {
+		return m.err
+	}
+	return json.Unmarshal([]byte(m.json), v)
+}
+
+func (m *mockReaper) sow_json(_ context.Context, method, path string, values map[string]string, v interface{}) error {
+	m.method = method
+	m.path = path
+	m.values = values
+	if m.err != nil || v == nil {
+		return m.err
+	}
+	return json.Unmarshal([]byte(m.json), v)
+}
+
 func reaperWhich(h Harvest, err error) *mockReaper {
 	return &mockReaper{
 		h:   h,
@@ -0,0 +1,653 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Moderator defines behaviors a moderator can perform on the posts and
+// comments of the subreddits it moderates. They require the "modposts" OAuth2
+// scope unless noted otherwise; without it, they fail with
+// InsufficientScopeErr.
+//
+// Every method has a Context variant which binds its request, including the
+// wait for the rate limit, to the given context.
+type Moderator interface {
+	// Approve approves a post or comment, given its name, restoring it if
+	// it was removed.
+	Approve(name string) error
+	ApproveContext(ctx context.Context, name string) error
+
+	// Remove removes a post or comment, given its name. If spam is true
+	// the item is marked as spam, which trains the subreddit's filter.
+	Remove(name string, spam bool) error
+	RemoveContext(ctx context.Context, name string, spam bool) error
+	// SetRemovalReason sets the removal reason of a removed post or
+	// comment, given its name, to one of the subreddit's removal reasons
+	// by id, with a note to the other moderators.
+	SetRemovalReason(name, reasonID, note string) error
+	SetRemovalReasonContext(ctx context.Context, name, reasonID, note string) error
+
+	// Lock and Unlock control whether a post or comment, given its name,
+	// can be replied to.
+	Lock(name string) error
+	LockContext(ctx context.Context, name string) error
+	Unlock(name string) error
+	UnlockContext(ctx context.Context, name string) error
+
+	// Sticky stickies a post, given its name, to the top of its
+	// subreddit, in the given slot (1 or 2). Unsticky unstickies it.
+	Sticky(name string, slot int) error
+	StickyContext(ctx context.Context, name string, slot int) error
+	Unsticky(name string) error
+	UnstickyContext(ctx context.Context, name string) error
+
+	// Distinguish marks a post or comment, given its name, as made by a
+	// moderator. If sticky is true and the item is a top level comment,
+	// it is also stickied to the top of its thread. Undistinguish removes
+	// the mark.
+	Distinguish(name string, sticky bool) error
+	DistinguishContext(ctx context.Context, name string, sticky bool) error
+	Undistinguish(name string) error
+	UndistinguishContext(ctx context.Context, name string) error
+
+	// MarkNSFW and UnmarkNSFW control whether a post, given its name, is
+	// marked not safe for work.
+	MarkNSFW(name string) error
+	MarkNSFWContext(ctx context.Context, name string) error
+	UnmarkNSFW(name string) error
+	UnmarkNSFWContext(ctx context.Context, name string) error
+
+	// MarkSpoiler and UnmarkSpoiler control whether a post, given its
+	// name, is marked as a spoiler.
+	MarkSpoiler(name string) error
+	MarkSpoilerContext(ctx context.Context, name string) error
+	UnmarkSpoiler(name string) error
+	UnmarkSpoilerContext(ctx context.Context, name string) error
+
+	// SetContestMode enables or disables contest mode on a post, given
+	// its name.
+	SetContestMode(name string, enabled bool) error
+	SetContestModeContext(ctx context.Context, name string, enabled bool) error
+
+	// SetSuggestedSort sets the suggested comment sort of a post, given
+	// its name, e.g. "new", "top" or "qa". An empty sort clears it.
+	SetSuggestedSort(name, sort string) error
+	SetSuggestedSortContext(ctx context.Context, name, sort string) error
+
+	// SetUserFlairCSV sets the flair of many users in a subreddit at once,
+	// in batches of 100. It requires the "modflair" OAuth2 scope. If
+	// Reddit rejects any of the flairs, the others are still set and an
+	// *APIError describes every rejected flair.
+	SetUserFlairCSV(subreddit string, flairs []UserFlair) error
+	SetUserFlairCSVContext(ctx context.Context, subreddit string, flairs []UserFlair) error
+
+	// Ban bans a user from a subreddit for the given number of days, or
+	// permanently if days is 0. The reason is one of the subreddit's rules
+	// or a short explanation, the note is kept for the other moderators,
+	// and the message is sent to the user. Unban lifts the ban. They
+	// require the "modcontributors" OAuth2 scope.
+	Ban(subreddit, user string, days int, reason, note, message string) error
+	BanContext(ctx context.Context, subreddit, user string, days int, reason, note, message string) error
+	Unban(subreddit, user string) error
+	UnbanContext(ctx context.Context, subreddit, user string) error
+
+	// Mute stops a user from messaging a subreddit's modmail, with a note
+	// for the other moderators. Unmute lifts the mute. They require the
+	// "modcontributors" OAuth2 scope.
+	Mute(subreddit, user, note string) error
+	MuteContext(ctx context.Context, subreddit, user, note string) error
+	Unmute(subreddit, user string) error
+	UnmuteContext(ctx context.Context, subreddit, user string) error
+
+	// AddContributor and RemoveContributor control whether a user is an
+	// approved contributor of a subreddit. They require the
+	// "modcontributors" OAuth2 scope.
+	AddContributor(subreddit, user string) error
+	AddContributorContext(ctx context.Context, subreddit, user string) error
+	RemoveContributor(subreddit, user string) error
+	RemoveContributorContext(ctx context.Context, subreddit, user string) error
+
+	// InviteModerator invites a user to moderate a subreddit with the
+	// given permissions, e.g. "posts" or "wiki", or all permissions if
+	// none are given. RemoveModerator removes a moderator. They require
+	// the "modothers" OAuth2 scope.
+	InviteModerator(subreddit, user string, permissions ...string) error
+	InviteModeratorContext(ctx context.Context, subreddit, user string, permissions ...string) error
+	RemoveModerator(subreddit, user string) error
+	RemoveModeratorContext(ctx context.Context, subreddit, user string) error
+
+	// Banned, Muted, Contributors and Moderators list the users with each
+	// relationship to a subreddit, reading every page of the list. They
+	// require the "read" OAuth2 scope.
+	Banned(subreddit string) ([]*Relationship, error)
+	BannedContext(ctx context.Context, subreddit string) ([]*Relationship, error)
+	Muted(subreddit string) ([]*Relationship, error)
+	MutedContext(ctx context.Context, subreddit string) ([]*Relationship, error)
+	Contributors(subreddit string) ([]*Relationship, error)
+	ContributorsContext(ctx context.Context, subreddit string) ([]*Relationship, error)
+	Moderators(subreddit string) ([]*Relationship, error)
+	ModeratorsContext(ctx context.Context, subreddit string) ([]*Relationship, error)
+}
+
+// Relationship types understood by /api/friend and /api/unfriend.
+const (
+	banned          = "banned"
+	muted           = "muted"
+	contributor     = "contributor"
+	moderatorInvite = "moderator_invite"
+	moderatorType   = "moderator"
+)
+
+type moderator struct {
+	// r is used to execute requests to Reddit.
+	r reaper
+}
+
+// newModerator returns a new Moderator using the given reaper to make requests
+// to Reddit.
+func newModerator(r reaper) Moderator {
+	return &moderator{
+		r: r,
+	}
+}
+
+func (m *moderator) Approve(name string) error {
+	return m.ApproveContext(context.Background(), name)
+}
+
+func (m *moderator) ApproveContext(ctx context.Context, name string) error {
+	return m.act(ctx, "/api/approve", name)
+}
+
+func (m *moderator) Remove(name string, spam bool) error {
+	return m.RemoveContext(context.Background(), name, spam)
+}
+
+func (m *moderator) RemoveContext(
+	ctx context.Context,
+	name string,
+	spam bool,
+) error {
+	return m.r.sow(
+		ctx, "/api/remove", map[string]string{
+			"id":   name,
+			"spam": strconv.FormatBool(spam),
+		},
+	)
+}
+
+func (m *moderator) SetRemovalReason(name, reasonID, note string) error {
+	return m.SetRemovalReasonContext(context.Background(), name, reasonID, note)
+}
+
+func (m *moderator) SetRemovalReasonContext(
+	ctx context.Context,
+	name, reasonID, note string,
+) error {
+	// This endpoint takes its parameters as a JSON document in the "json"
+	// form value.
+	reason, err := json.Marshal(
+		struct {
+			ItemIDs  []string `json:"item_ids"`
+			ReasonID string   `json:"reason_id"`
+			ModNote  string   `json:"mod_note"`
+		}{
+			ItemIDs:  []string{name},
+			ReasonID: reasonID,
+			ModNote:  note,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return m.r.sow(
+		ctx, "/api/v1/modactions/removal_reasons", map[string]string{
+			"json": string(reason),
+		},
+	)
+}
+
+func (m *moderator) Lock(name string) error {
+	return m.LockContext(context.Background(), name)
+}
+
+func (m *moderator) LockContext(ctx context.Context, name string) error {
+	return m.act(ctx, "/api/lock", name)
+}
+
+func (m *moderator) Unlock(name string) error {
+	return m.UnlockContext(context.Background(), name)
+}
+
+func (m *moderator) UnlockContext(ctx context.Context, name string) error {
+	return m.act(ctx, "/api/unlock", name)
+}
+
+func (m *moderator) Sticky(name string, slot int) error {
+	return m.StickyContext(context.Background(), name, slot)
+}
+
+func (m *moderator) StickyContext(
+	ctx context.Context,
+	name string,
+	slot int,
+) error {
+	return m.r.sow(
+		ctx, "/api/set_subreddit_sticky", map[string]string{
+			"id":    name,
+			"state": "true",
+			"num":   strconv.Itoa(slot),
+		},
+	)
+}
+
+func (m *moderator) Unsticky(name string) error {
+	return m.UnstickyContext(context.Background(), name)
+}
+
+func (m *moderator) UnstickyContext(ctx context.Context, name string) error {
+	return m.r.sow(
+		ctx, "/api/set_subreddit_sticky", map[string]string{
+			"id":    name,
+			"state": "false",
+		},
+	)
+}
+
+func (m *moderator) Distinguish(name string, sticky bool) error {
+	return m.DistinguishContext(context.Background(), name, sticky)
+}
+
+func (m *moderator) DistinguishContext(
+	ctx context.Context,
+	name string,
+	sticky bool,
+) error {
+	return m.r.sow(
+		ctx, "/api/distinguish", map[string]string{
+			"id":     name,
+			"how":    "yes",
+			"sticky": strconv.FormatBool(sticky),
+		},
+	)
+}
+
+func (m *moderator) Undistinguish(name string) error {
+	return m.UndistinguishContext(context.Background(), name)
+}
+
+func (m *moderator) UndistinguishContext(ctx context.Context, name string) error {
+	return m.r.sow(
+		ctx, "/api/distinguish", map[string]string{
+			"id":  name,
+			"how": "no",
+		},
+	)
+}
+
+func (m *moderator) MarkNSFW(name string) error {
+	return m.MarkNSFWContext(context.Background(), name)
+}
+
+func (m *moderator) MarkNSFWContext(ctx context.Context, name string) error {
+	return m.act(ctx, "/api/marknsfw", name)
+}
+
+func (m *moderator) UnmarkNSFW(name string) error {
+	return m.UnmarkNSFWContext(context.Background(), name)
+}
+
+func (m *moderator) UnmarkNSFWContext(ctx context.Context, name string) error {
+	return m.act(ctx, "/api/unmarknsfw", name)
+}
+
+func (m *moderator) MarkSpoiler(name string) error {
+	return m.MarkSpoilerContext(context.Background(), name)
+}
+
+func (m *moderator) MarkSpoilerContext(ctx context.Context, name string) error {
+	return m.act(ctx, "/api/spoiler", name)
+}
+
+func (m *moderator) UnmarkSpoiler(name string) error {
+	return m.UnmarkSpoilerContext(context.Background(), name)
+}
+
+func (m *moderator) UnmarkSpoilerContext(ctx context.Context, name string) error {
+	return m.act(ctx, "/api/unspoiler", name)
+}
+
+func (m *moderator) SetContestMode(name string, enabled bool) error {
+	return m.SetContestModeContext(context.Background(), name, enabled)
+}
+
+func (m *moderator) SetContestModeContext(
+	ctx context.Context,
+	name string,
+	enabled bool,
+) error {
+	return m.r.sow(
+		ctx, "/api/set_contest_mode", map[string]string{
+			"id":    name,
+			"state": strconv.FormatBool(enabled),
+		},
+	)
+}
+
+func (m *moderator) SetSuggestedSort(name, sort string) error {
+	return m.SetSuggestedSortContext(context.Background(), name, sort)
+}
+
+func (m *moderator) SetSuggestedSortContext(
+	ctx context.Context,
+	name, sort string,
+) error {
+	// Reddit clears the suggested sort when it is set to "blank".
+	if sort == "" {
+		sort = "blank"
+	}
+
+	return m.r.sow(
+		ctx, "/api/set_suggested_sort", map[string]string{
+			"id":   name,
+			"sort": sort,
+		},
+	)
+}
+
+func (m *moderator) SetUserFlairCSV(subreddit string, flairs []UserFlair) error {
+	return m.SetUserFlairCSVContext(context.Background(), subreddit, flairs)
+}
+
+func (m *moderator) SetUserFlairCSVContext(
+	ctx context.Context,
+	subreddit string,
+	flairs []UserFlair,
+) error {
+	var results []flairCSVResult
+	for start := 0; start < len(flairs); start += flairCSVLines {
+		end := start + flairCSVLines
+		if end > len(flairs) {
+			end = len(flairs)
+		}
+
+		// Reddit reports on every line of the upload, in order.
+		lines, err := flairCSV(flairs[start:end])
+		if err != nil {
+			return err
+		}
+
+		var batchResults []flairCSVResult
+		if err := m.r.sow_json(
+			ctx, "POST", "/r/"+subreddit+"/api/flaircsv", map[string]string{
+				"flair_csv": lines,
+			}, &batchResults,
+		); err != nil {
+			return err
+		}
+		results = append(results, batchResults...)
+	}
+
+	return flairCSVErrors(flairs, results)
+}
+
+func (m *moderator) Ban(
+	subreddit, user string,
+	days int,
+	reason, note, message string,
+) error {
+	return m.BanContext(
+		context.Background(),
+		subreddit,
+		user,
+		days,
+		reason,
+		note,
+		message,
+	)
+}
+
+func (m *moderator) BanContext(
+	ctx context.Context,
+	subreddit, user string,
+	days int,
+	reason, note, message string,
+) error {
+	values := map[string]string{
+		"ban_reason":  reason,
+		"note":        note,
+		"ban_message": message,
+	}
+	if days > 0 {
+		values["duration"] = strconv.Itoa(days)
+	}
+
+	return m.friend(ctx, subreddit, user, banned, values)
+}
+
+func (m *moderator) Unban(subreddit, user string) error {
+	return m.UnbanContext(context.Background(), subreddit, user)
+}
+
+func (m *moderator) UnbanContext(ctx context.Context, subreddit, user string) error {
+	return m.unfriend(ctx, subreddit, user, banned)
+}
+
+func (m *moderator) Mute(subreddit, user, note string) error {
+	return m.MuteContext(context.Background(), subreddit, user, note)
+}
+
+func (m *moderator) MuteContext(
+	ctx context.Context,
+	subreddit, user, note string,
+) error {
+	return m.friend(
+		ctx, subreddit, user, muted, map[string]string{
+			"note": note,
+		},
+	)
+}
+
+func (m *moderator) Unmute(subreddit, user string) error {
+	return m.UnmuteContext(context.Background(), subreddit, user)
+}
+
+func (m *moderator) UnmuteContext(ctx context.Context, subreddit, user string) error {
+	return m.unfriend(ctx, subreddit, user, muted)
+}
+
+func (m *moderator) AddContributor(subreddit, user string) error {
+	return m.AddContributorContext(context.Background(), subreddit, user)
+}
+
+func (m *moderator) AddContributorContext(
+	ctx context.Context,
+	subreddit, user string,
+) error {
+	return m.friend(ctx, subreddit, user, contributor, nil)
+}
+
+func (m *moderator) RemoveContributor(subreddit, user string) error {
+	return m.RemoveContributorContext(context.Background(), subreddit, user)
+}
+
+func (m *moderator) RemoveContributorContext(
+	ctx context.Context,
+	subreddit, user string,
+) error {
+	return m.unfriend(ctx, subreddit, user, contributor)
+}
+
+func (m *moderator) InviteModerator(
+	subreddit, user string,
+	permissions ...string,
+) error {
+	return m.InviteModeratorContext(
+		context.Background(),
+		subreddit,
+		user,
+		permissions...,
+	)
+}
+
+func (m *moderator) InviteModeratorContext(
+	ctx context.Context,
+	subreddit, user string,
+	permissions ...string,
+) error {
+	// Reddit takes permissions as a list of changes to the default of no
+	// permissions, e.g. "+posts,+wiki".
+	if len(permissions) == 0 {
+		permissions = []string{"all"}
+	}
+	changes := make([]string, len(permissions))
+	for i, p := range permissions {
+		changes[i] = "+" + p
+	}
+
+	return m.friend(
+		ctx, subreddit, user, moderatorInvite, map[string]string{
+			"permissions": strings.Join(changes, ","),
+		},
+	)
+}
+
+func (m *moderator) RemoveModerator(subreddit, user string) error {
+	return m.RemoveModeratorContext(context.Background(), subreddit, user)
+}
+
+func (m *moderator) RemoveModeratorContext(
+	ctx context.Context,
+	subreddit, user string,
+) error {
+	return m.unfriend(ctx, subreddit, user, moderatorType)
+}
+
+func (m *moderator) Banned(subreddit string) ([]*Relationship, error) {
+	return m.BannedContext(context.Background(), subreddit)
+}
+
+func (m *moderator) BannedContext(
+	ctx context.Context,
+	subreddit string,
+) ([]*Relationship, error) {
+	return m.relationships(ctx, subreddit, "banned")
+}
+
+func (m *moderator) Muted(subreddit string) ([]*Relationship, error) {
+	return m.MutedContext(context.Background(), subreddit)
+}
+
+func (m *moderator) MutedContext(
+	ctx context.Context,
+	subreddit string,
+) ([]*Relationship, error) {
+	return m.relationships(ctx, subreddit, "muted")
+}
+
+func (m *moderator) Contributors(subreddit string) ([]*Relationship, error) {
+	return m.ContributorsContext(context.Background(), subreddit)
+}
+
+func (m *moderator) ContributorsContext(
+	ctx context.Context,
+	subreddit string,
+) ([]*Relationship, error) {
+	return m.relationships(ctx, subreddit, "contributors")
+}
+
+func (m *moderator) Moderators(subreddit string) ([]*Relationship, error) {
+	return m.ModeratorsContext(context.Background(), subreddit)
+}
+
+func (m *moderator) ModeratorsContext(
+	ctx context.Context,
+	subreddit string,
+) ([]*Relationship, error) {
+	return m.relationships(ctx, subreddit, "moderators")
+}
+
+// friend creates a relationship of the given type between a user and a
+// subreddit, with any further values the type takes.
+func (m *moderator) friend(
+	ctx context.Context,
+	subreddit, user, kind string,
+	extra map[string]string,
+) error {
+	values := map[string]string{
+		"name": user,
+		"type": kind,
+	}
+	for key, value := range extra {
+		values[key] = value
+	}
+
+	return m.r.sow(ctx, "/r/"+subreddit+"/api/friend", values)
+}
+
+// unfriend ends a relationship of the given type between a user and a
+// subreddit.
+func (m *moderator) unfriend(
+	ctx context.Context,
+	subreddit, user, kind string,
+) error {
+	return m.r.sow(
+		ctx, "/r/"+subreddit+"/api/unfriend", map[string]string{
+			"name": user,
+			"type": kind,
+		},
+	)
+}
+
+// relationships reads every page of one of a subreddit's lists of users, such
+// as "banned" or "moderators".
+func (m *moderator) relationships(
+	ctx context.Context,
+	subreddit, list string,
+) ([]*Relationship, error) {
+	path := "/r/" + subreddit + "/about/" + list
+	relationships := []*Relationship{}
+	after := ""
+	for {
+		var page struct {
+			Data struct {
+				After    string                   `json:"after"`
+				Children []map[string]interface{} `json:"children"`
+			} `json:"data"`
+		}
+		if err := m.r.reap_json(
+			ctx, path, map[string]string{
+				"limit": "100",
+				"after": after,
+			}, &page,
+		); err != nil {
+			return nil, err
+		}
+
+		for _, child := range page.Data.Children {
+			r, err := parseRelationship(child)
+			if err != nil {
+				return nil, err
+			}
+			relationships = append(relationships, r)
+		}
+
+		if page.Data.After == "" || page.Data.After == after {
+			return relationships, nil
+		}
+		after = page.Data.After
+	}
+}
+
+// act performs a moderator action which takes only the name of the item.
+func (m *moderator) act(ctx context.Context, path, name string) error {
+	return m.r.sow(
+		ctx, path, map[string]string{
+			"id": name,
+		},
+	)
+}
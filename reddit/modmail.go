@@ -0,0 +1,295 @@
+package reddit
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// modmailPath is the path of the modmail conversations endpoint.
+const modmailPath = "/api/mod/conversations"
+
+// Conversation represents a modmail conversation. Modmail is not served in
+// listings of things like the rest of Reddit, so conversations have no full
+// name; they are identified by their ID.
+type Conversation struct {
+	ID      string `json:"id"`
+	Subject string `json:"subject"`
+	// Subreddit is the subreddit the conversation is with.
+	Subreddit string `json:"-"`
+
+	// State is 0 for new, 1 for in progress and 2 for archived
+	// conversations.
+	State         int  `json:"state"`
+	IsInternal    bool `json:"isInternal"`
+	IsHighlighted bool `json:"isHighlighted"`
+	IsAuto        bool `json:"isAuto"`
+	NumMessages   int  `json:"numMessages"`
+
+	LastUpdated    time.Time `json:"lastUpdated"`
+	LastUserUpdate time.Time `json:"lastUserUpdate"`
+	LastModUpdate  time.Time `json:"lastModUpdate"`
+
+	Authors     []ModmailAuthor `json:"authors"`
+	Participant ModmailAuthor   `json:"participant"`
+
+	// Messages are the messages of the conversation which Reddit included
+	// in the response, oldest first. Listings of conversations include only
+	// the most recent message of each; use Conversation for all of them.
+	Messages []*ModmailMessage `json:"-"`
+}
+
+// conversation wraps the user facing Conversation type with the fields Reddit
+// uses to describe its subreddit and messages.
+type conversation struct {
+	Conversation
+	Owner struct {
+		DisplayName string `json:"displayName"`
+	} `json:"owner"`
+	ObjIDs []struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	} `json:"objIds"`
+}
+
+// ModmailMessage represents a message in a modmail conversation.
+type ModmailMessage struct {
+	ID string `json:"id"`
+	// ConversationID and Subreddit identify the conversation the message
+	// belongs to.
+	ConversationID string `json:"-"`
+	Subreddit      string `json:"-"`
+
+	Author       ModmailAuthor `json:"author"`
+	Body         string        `json:"body"`
+	BodyMarkdown string        `json:"bodyMarkdown"`
+	Date         time.Time     `json:"date"`
+	IsInternal   bool          `json:"isInternal"`
+}
+
+// ModmailAuthor represents a participant in a modmail conversation.
+type ModmailAuthor struct {
+	Name          string `json:"name"`
+	IsMod         bool   `json:"isMod"`
+	IsAdmin       bool   `json:"isAdmin"`
+	IsOP          bool   `json:"isOp"`
+	IsParticipant bool   `json:"isParticipant"`
+	IsHidden      bool   `json:"isHidden"`
+	IsDeleted     bool   `json:"isDeleted"`
+}
+
+// conversationList is the response describing many conversations.
+type conversationList struct {
+	Conversations   map[string]*conversation   `json:"conversations"`
+	ConversationIDs []string                   `json:"conversationIds"`
+	Messages        map[string]*ModmailMessage `json:"messages"`
+}
+
+// conversationDetail is the response describing one conversation.
+type conversationDetail struct {
+	Conversation *conversation              `json:"conversation"`
+	Messages     map[string]*ModmailMessage `json:"messages"`
+}
+
+// Modmail defines behaviors for reading and answering the modmail of the
+// subreddits an account moderates. They require the "modmail" OAuth2 scope.
+//
+// Every method has a Context variant which binds its request, including the
+// wait for the rate limit, to the given context.
+type Modmail interface {
+	// Conversations lists up to limit of the most recently updated
+	// conversations of the subreddits (all moderated subreddits if none
+	// are given) in the given state, e.g. "all", "new", "inprogress",
+	// "mod", "archived" or "highlighted".
+	Conversations(state string, limit int, subreddits ...string) ([]*Conversation, error)
+	ConversationsContext(ctx context.Context, state string, limit int, subreddits ...string) ([]*Conversation, error)
+
+	// Conversation returns a conversation, given its ID, with all of its
+	// messages. If markRead is true the conversation is marked read.
+	Conversation(id string, markRead bool) (*Conversation, error)
+	ConversationContext(ctx context.Context, id string, markRead bool) (*Conversation, error)
+
+	// ReplyModmail replies to a conversation, given its ID, and returns
+	// the updated conversation. If internal is true the reply is a private
+	// moderator note.
+	ReplyModmail(id, body string, internal bool) (*Conversation, error)
+	ReplyModmailContext(ctx context.Context, id, body string, internal bool) (*Conversation, error)
+
+	// ArchiveConversation and UnarchiveConversation move a conversation,
+	// given its ID, in and out of the archive.
+	ArchiveConversation(id string) error
+	ArchiveConversationContext(ctx context.Context, id string) error
+	UnarchiveConversation(id string) error
+	UnarchiveConversationContext(ctx context.Context, id string) error
+
+	// HighlightConversation and UnhighlightConversation control whether a
+	// conversation, given its ID, is highlighted.
+	HighlightConversation(id string) error
+	HighlightConversationContext(ctx context.Context, id string) error
+	UnhighlightConversation(id string) error
+	UnhighlightConversationContext(ctx context.Context, id string) error
+}
+
+type modmail struct {
+	// r is used to execute requests to Reddit.
+	r reaper
+}
+
+// newModmail returns a new Modmail using the given reaper to make requests to
+// Reddit.
+func newModmail(r reaper) Modmail {
+	return &modmail{
+		r: r,
+	}
+}
+
+func (m *modmail) Conversations(
+	state string,
+	limit int,
+	subreddits ...string,
+) ([]*Conversation, error) {
+	return m.ConversationsContext(context.Background(), state, limit, subreddits...)
+}
+
+func (m *modmail) ConversationsContext(
+	ctx context.Context,
+	state string,
+	limit int,
+	subreddits ...string,
+) ([]*Conversation, error) {
+	values := map[string]string{
+		"sort":  "recent",
+		"state": state,
+		"limit": strconv.Itoa(limit),
+	}
+	if len(subreddits) > 0 {
+		values["entity"] = strings.Join(subreddits, ",")
+	}
+
+	var list conversationList
+	if err := m.r.reap_json(ctx, modmailPath, values, &list); err != nil {
+		return nil, err
+	}
+
+	conversations := []*Conversation{}
+	for _, id := range list.ConversationIDs {
+		if c, ok := list.Conversations[id]; ok {
+			conversations = append(conversations, assemble(c, list.Messages))
+		}
+	}
+	return conversations, nil
+}
+
+func (m *modmail) Conversation(id string, markRead bool) (*Conversation, error) {
+	return m.ConversationContext(context.Background(), id, markRead)
+}
+
+func (m *modmail) ConversationContext(
+	ctx context.Context,
+	id string,
+	markRead bool,
+) (*Conversation, error) {
+	var detail conversationDetail
+	if err := m.r.reap_json(
+		ctx, modmailPath+"/"+id, map[string]string{
+			"markRead": strconv.FormatBool(markRead),
+		}, &detail,
+	); err != nil {
+		return nil, err
+	}
+
+	return detail.conversation(), nil
+}
+
+func (m *modmail) ReplyModmail(id, body string, internal bool) (*Conversation, error) {
+	return m.ReplyModmailContext(context.Background(), id, body, internal)
+}
+
+func (m *modmail) ReplyModmailContext(
+	ctx context.Context,
+	id, body string,
+	internal bool,
+) (*Conversation, error) {
+	var detail conversationDetail
+	if err := m.r.sow_json(
+		ctx, "POST", modmailPath+"/"+id, map[string]string{
+			"body":           body,
+			"isInternal":     strconv.FormatBool(internal),
+			"isAuthorHidden": "false",
+		}, &detail,
+	); err != nil {
+		return nil, err
+	}
+
+	return detail.conversation(), nil
+}
+
+func (m *modmail) ArchiveConversation(id string) error {
+	return m.ArchiveConversationContext(context.Background(), id)
+}
+
+func (m *modmail) ArchiveConversationContext(ctx context.Context, id string) error {
+	return m.r.sow_json(ctx, "POST", modmailPath+"/"+id+"/archive", nil, nil)
+}
+
+func (m *modmail) UnarchiveConversation(id string) error {
+	return m.UnarchiveConversationContext(context.Background(), id)
+}
+
+func (m *modmail) UnarchiveConversationContext(ctx context.Context, id string) error {
+	return m.r.sow_json(ctx, "POST", modmailPath+"/"+id+"/unarchive", nil, nil)
+}
+
+func (m *modmail) HighlightConversation(id string) error {
+	return m.HighlightConversationContext(context.Background(), id)
+}
+
+func (m *modmail) HighlightConversationContext(ctx context.Context, id string) error {
+	return m.r.sow_json(ctx, "POST", modmailPath+"/"+id+"/highlight", nil, nil)
+}
+
+func (m *modmail) UnhighlightConversation(id string) error {
+	return m.UnhighlightConversationContext(context.Background(), id)
+}
+
+func (m *modmail) UnhighlightConversationContext(ctx context.Context, id string) error {
+	return m.r.sow_json(ctx, "DELETE", modmailPath+"/"+id+"/highlight", nil, nil)
+}
+
+// conversation returns the conversation in the response, with its messages.
+func (d conversationDetail) conversation() *Conversation {
+	if d.Conversation == nil {
+		return &Conversation{}
+	}
+	return assemble(d.Conversation, d.Messages)
+}
+
+// assemble fills in the fields of a conversation which Reddit describes
+// elsewhere in its response: its subreddit and those of its messages which
+// are in the response, ordered oldest first.
+func assemble(
+	raw *conversation,
+	messages map[string]*ModmailMessage,
+) *Conversation {
+	c := &raw.Conversation
+	c.Subreddit = raw.Owner.DisplayName
+	c.Messages = nil
+	for _, obj := range raw.ObjIDs {
+		if obj.Key != "messages" {
+			continue
+		}
+
+		if msg, ok := messages[obj.ID]; ok {
+			msg.ConversationID = c.ID
+			msg.Subreddit = c.Subreddit
+			c.Messages = append(c.Messages, msg)
+		}
+	}
+
+	sort.SliceStable(c.Messages, func(i, j int) bool {
+		return c.Messages[i].Date.Before(c.Messages[j].Date)
+	})
+	return c
+}
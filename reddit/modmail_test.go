@@ -0,0 +1,138 @@
+package reddit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+const conversationListJSON = `{
+	"conversations": {
+		"c2": {
+			"id": "c2",
+			"subject": "second",
+			"owner": {"displayName": "sub", "type": "subreddit"},
+			"lastUpdated": "2020-01-02T00:00:00.000000+00:00",
+			"objIds": [{"id": "m2", "key": "messages"}]
+		},
+		"c1": {
+			"id": "c1",
+			"subject": "first",
+			"owner": {"displayName": "sub", "type": "subreddit"},
+			"lastUpdated": "2020-01-01T00:00:00.000000+00:00",
+			"objIds": [{"id": "m1", "key": "messages"}, {"id": "a1", "key": "modActions"}]
+		}
+	},
+	"conversationIds": ["c2", "c1"],
+	"messages": {
+		"m1": {"id": "m1", "bodyMarkdown": "hi", "author": {"name": "user"}, "date": "2020-01-01T00:00:00.000000+00:00"},
+		"m2": {"id": "m2", "bodyMarkdown": "hey", "author": {"name": "mod", "isMod": true}, "date": "2020-01-02T00:00:00.000000+00:00"}
+	}
+}`
+
+const conversationDetailJSON = `{
+	"conversation": {
+		"id": "c1",
+		"subject": "first",
+		"owner": {"displayName": "sub", "type": "subreddit"},
+		"objIds": [{"id": "m2", "key": "messages"}, {"id": "m1", "key": "messages"}]
+	},
+	"messages": {
+		"m1": {"id": "m1", "bodyMarkdown": "hi", "date": "2020-01-01T00:00:00.000000+00:00"},
+		"m2": {"id": "m2", "bodyMarkdown": "hey", "date": "2020-01-02T00:00:00.000000+00:00"}
+	}
+}`
+
+func TestConversations(t *testing.T) {
+	r := &mockReaper{json: conversationListJSON}
+	convs, err := newModmail(r).Conversations("all", 10, "sub", "other")
+	if err != nil {
+		t.Fatalf("failed to list conversations: %v", err)
+	}
+
+	if r.path != "/api/mod/conversations" {
+		t.Errorf("wrong path; got %s", r.path)
+	}
+
+	if diff := pretty.Compare(r.values, map[string]string{
+		"entity": "sub,other",
+		"limit":  "10",
+		"sort":   "recent",
+		"state":  "all",
+	}); diff != "" {
+		t.Errorf("wrong values; diff: %s", diff)
+	}
+
+	if len(convs) != 2 || convs[0].ID != "c2" || convs[1].ID != "c1" {
+		t.Fatalf("conversations out of order: %v", pretty.Sprint(convs))
+	}
+
+	first := convs[1]
+	if first.Subreddit != "sub" ||
+		!first.LastUpdated.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("conversation parsed incorrectly: %+v", first)
+	}
+
+	if len(first.Messages) != 1 {
+		t.Fatalf("wanted 1 message; got %d", len(first.Messages))
+	}
+
+	if msg := first.Messages[0]; msg.ID != "m1" || msg.ConversationID != "c1" ||
+		msg.Subreddit != "sub" || msg.Author.Name != "user" {
+		t.Errorf("message parsed incorrectly: %+v", msg)
+	}
+}
+
+func TestConversation(t *testing.T) {
+	r := &mockReaper{json: conversationDetailJSON}
+	conv, err := newModmail(r).Conversation("c1", true)
+	if err != nil {
+		t.Fatalf("failed to read conversation: %v", err)
+	}
+
+	if r.path != "/api/mod/conversations/c1" || r.values["markRead"] != "true" {
+		t.Errorf("wrong request: %s, %v", r.path, r.values)
+	}
+
+	if len(conv.Messages) != 2 || conv.Messages[0].ID != "m1" ||
+		conv.Messages[1].ID != "m2" {
+		t.Errorf("wanted messages oldest first; got %v", pretty.Sprint(conv.Messages))
+	}
+}
+
+func TestModmailActions(t *testing.T) {
+	r := &mockReaper{json: conversationDetailJSON}
+	m := newModmail(r)
+
+	if _, err := m.ReplyModmail("c1", "text", true); err != nil {
+		t.Fatalf("failed to reply: %v", err)
+	}
+
+	if r.method != "POST" || r.path != "/api/mod/conversations/c1" ||
+		r.values["body"] != "text" || r.values["isInternal"] != "true" {
+		t.Errorf("wrong reply request: %s %s %v", r.method, r.path, r.values)
+	}
+
+	for i, test := range []struct {
+		f      func(string) error
+		method string
+		path   string
+	}{
+		{m.ArchiveConversation, "POST", "/api/mod/conversations/c1/archive"},
+		{m.UnarchiveConversation, "POST", "/api/mod/conversations/c1/unarchive"},
+		{m.HighlightConversation, "POST", "/api/mod/conversations/c1/highlight"},
+		{m.UnhighlightConversation, "DELETE", "/api/mod/conversations/c1/highlight"},
+	} {
+		if err := test.f("c1"); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+
+		if r.method != test.method || r.path != test.path {
+			t.Errorf(
+				"%d: got %s %s; wanted %s %s",
+				i, r.method, r.path, test.method, test.path,
+			)
+		}
+	}
+}
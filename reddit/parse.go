@@ -13,8 +13,23 @@ const (
 	commentKind = "t1"
 	messageKind = "t4"
 	moreKind    = "more"
+	// modActionKind is the kind of moderation log entries.
+	modActionKind = "modaction"
+	subredditKind = "t5"
+	redditorKind  = "t2"
 )
 
+// knownKinds are the kinds of things the parser understands.
+var knownKinds = map[string]bool{
+	postKind:      true,
+	commentKind:   true,
+	messageKind:   true,
+	moreKind:      true,
+	modActionKind: true,
+	subredditKind: true,
+	redditorKind:  true,
+}
+
 // author fields and body fields are set to the deletedKey if the user deletes
 // their post.
 const deletedKey = "[deleted]"
@@ -44,8 +59,11 @@ type comment struct {
 // parser parses Reddit responses..
 type parser interface {
 	// parse parses any Reddit response and provides the elements in it.
-	parse(blob json.RawMessage) ([]*Comment, []*Post, []*Message, []*More, error)
+	parse(blob json.RawMessage) (Harvest, error)
 	parse_submitted(blob json.RawMessage) (Submission, error)
+	// parse_errors parses the errors Reddit reports in the JSON response
+	// to a write request.
+	parse_errors(blob json.RawMessage) error
 }
 
 type parserImpl struct{}
@@ -55,27 +73,32 @@ func newParser() parser {
 }
 
 // parse parses any Reddit response and provides the elements in it.
-func (p *parserImpl) parse(
-	blob json.RawMessage,
-) ([]*Comment, []*Post, []*Message, []*More, error) {
-	comments, posts, msgs, mores, listingErr := parseRawListing(blob)
+func (p *parserImpl) parse(blob json.RawMessage) (Harvest, error) {
+	h, listingErr := parseRawListing(blob)
 	if listingErr == nil {
-		return comments, posts, msgs, mores, nil
+		return h, nil
 	}
 
 	post, threadErr := parseThread(blob)
 	if threadErr == nil {
-		return nil, []*Post{post}, nil, nil, nil
+		return Harvest{Posts: []*Post{post}}, nil
+	}
+
+	// Some endpoints, like /r/{subreddit}/about, respond with a single
+	// thing rather than a listing of them.
+	h, thingErr := parseRawThing(blob)
+	if thingErr == nil {
+		return h, nil
 	}
 
 	comments, mores, moreErr := parseMoreChildren(blob)
 	if moreErr == nil {
-		return comments, nil, nil, mores, nil
+		return Harvest{Comments: comments, Mores: mores}, nil
 	}
 
-	return nil, nil, nil, nil, fmt.Errorf(
-		"failed to parse as listing [%v], thread [%v], or more [%v]",
-		listingErr, threadErr, moreErr,
+	return Harvest{}, fmt.Errorf(
+		"failed to parse as listing [%v], thread [%v], thing [%v], or more [%v]",
+		listingErr, threadErr, thingErr, moreErr,
 	)
 }
 
@@ -89,8 +112,8 @@ func (p *parserImpl) parse_submitted(blob json.RawMessage) (Submission, error) {
 	}
 
 	wrapped = wrapped["json"].(map[string]interface{})
-	if len(wrapped["errors"].([]interface{})) != 0 {
-		return Submission{}, fmt.Errorf("API errors were returned: %v", wrapped["errors"])
+	if errs, _ := wrapped["errors"].([]interface{}); len(errs) != 0 {
+		return Submission{}, jsonErrors(errs)
 	}
 
 	data := wrapped["data"].(map[string]interface{})
@@ -110,18 +133,53 @@ func (p *parserImpl) parse_submitted(blob json.RawMessage) (Submission, error) {
 	return submission, err
 }
 
+// parse_errors parses the errors in a JSON response from Reddit of the form
+// {"json": {"errors": [...]}}. Responses without errors, including empty ones
+// and those from endpoints which respond with an empty object, return nil.
+func (p *parserImpl) parse_errors(blob json.RawMessage) error {
+	if len(blob) == 0 {
+		return nil
+	}
+
+	var wrapped struct {
+		JSON struct {
+			Errors []interface{} `json:"errors"`
+		} `json:"json"`
+	}
+	if err := json.Unmarshal(blob, &wrapped); err != nil {
+		return err
+	}
+
+	return jsonErrors(wrapped.JSON.Errors)
+}
+
 // parseRawListing parses a listing json blob and returns the elements in it.
-func parseRawListing(
-	blob json.RawMessage,
-) ([]*Comment, []*Post, []*Message, []*More, error) {
+func parseRawListing(blob json.RawMessage) (Harvest, error) {
 	var activityListing thing
 	if err := json.Unmarshal(blob, &activityListing); err != nil {
-		return nil, nil, nil, nil, err
+		return Harvest{}, err
 	}
 
 	return parseListing(&activityListing)
 }
 
+// parseRawThing parses a json blob holding a single thing of a known kind and
+// returns it.
+func parseRawThing(blob json.RawMessage) (Harvest, error) {
+	var t thing
+	if err := json.Unmarshal(blob, &t); err != nil {
+		return Harvest{}, err
+	}
+
+	if !knownKinds[t.Kind] {
+		return Harvest{}, fmt.Errorf(
+			"thing is of unknown kind %q", t.Kind,
+		)
+	}
+
+	return parseChildren([]thing{t})
+}
+
 // parseMoreChildren parses the json blob from /api/morechildren calls and returns the elements in it.
 func parseMoreChildren(
 	blob json.RawMessage,
@@ -132,12 +190,18 @@ func parseMoreChildren(
 		return nil, nil, err
 	}
 
-	wrapped = wrapped["json"].(map[string]interface{})
-	if len(wrapped["errors"].([]interface{})) != 0 {
-		return nil, nil, fmt.Errorf("API errors were returned: %v", wrapped["errors"])
+	wrapped, ok := wrapped["json"].(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("no json object in response")
+	}
+	if errs, _ := wrapped["errors"].([]interface{}); len(errs) != 0 {
+		return nil, nil, jsonErrors(errs)
 	}
 
-	data := wrapped["data"].(map[string]interface{})
+	data, ok := wrapped["data"].(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("no data in response")
+	}
 	// More submissions are further wrapped in a things block,
 	// so reorganize data so that it makes sense
 	things, hasThings := data["things"].([]interface{})
@@ -157,8 +221,8 @@ func parseMoreChildren(
 		return nil, nil, fmt.Errorf("%v", m.Errors)
 	}
 
-	comments, _, _, mores, err := parseChildren(m.Data)
-	return comments, mores, err
+	h, err := parseChildren(m.Data)
+	return h.Comments, h.Mores, err
 }
 
 // parseThread parses a post from a thread json blob returned by Reddit.
@@ -171,20 +235,22 @@ func parseThread(blob json.RawMessage) (*Post, error) {
 		return nil, err
 	}
 
-	_, posts, _, _, err := parseListing(&listings[0])
+	h, err := parseListing(&listings[0])
 	if err != nil {
 		return nil, err
 	}
 
+	posts := h.Posts
 	if len(posts) != 1 {
 		return nil, fmt.Errorf("expected 1 post; found %d", len(posts))
 	}
 
-	comments, _, _, mores, err := parseListing(&listings[1])
+	h, err = parseListing(&listings[1])
 	if err != nil {
 		return nil, err
 	}
 
+	comments, mores := h.Comments, h.Mores
 	// a submission should only have one more object
 	if len(mores) == 1 {
 		posts[0].More = mores[0]
@@ -197,25 +263,30 @@ func parseThread(blob json.RawMessage) (*Post, error) {
 }
 
 // parseListing parses a Reddit listing type and returns the elements inside it.
-func parseListing(t *thing) ([]*Comment, []*Post, []*Message, []*More, error) {
+func parseListing(t *thing) (Harvest, error) {
 	if t.Kind != listingKind {
-		return nil, nil, nil, nil, fmt.Errorf("thing is not listing")
+		return Harvest{}, fmt.Errorf("thing is not listing")
 	}
 
 	l := &listing{}
 	if err := mapstructure.Decode(t.Data, l); err != nil {
-		return nil, nil, nil, nil, mapDecodeError(err, t.Data)
+		return Harvest{}, mapDecodeError(err, t.Data)
 	}
 
 	return parseChildren(l.Children)
 }
 
 // parseChildren returns a list of parsed objects from the given list of things
-func parseChildren(children []thing) ([]*Comment, []*Post, []*Message, []*More, error) {
-	comments := []*Comment{}
-	posts := []*Post{}
-	msgs := []*Message{}
-	mores := []*More{}
+func parseChildren(children []thing) (Harvest, error) {
+	h := Harvest{
+		Comments:   []*Comment{},
+		Posts:      []*Post{},
+		Messages:   []*Message{},
+		Mores:      []*More{},
+		ModActions: []*ModAction{},
+		Subreddits: []*Subreddit{},
+		Redditors:  []*Redditor{},
+	}
 	err := error(nil)
 
 	for _, c := range children {
@@ -227,6 +298,9 @@ func parseChildren(children []thing) ([]*Comment, []*Post, []*Message, []*More,
 		var post *Post
 		var msg *Message
 		var more *More
+		var action *ModAction
+		var sub *Subreddit
+		var user *Redditor
 
 		// Reddit sets the "Kind" field of comments in the inbox, which
 		// have only Message and not Comment fields, to commentKind. The
@@ -235,20 +309,29 @@ func parseChildren(children []thing) ([]*Comment, []*Post, []*Message, []*More,
 		// hate programmers.
 		if c.Kind == messageKind || c.Data["was_comment"] != nil {
 			msg, err = parseMessage(&c)
-			msgs = append(msgs, msg)
+			h.Messages = append(h.Messages, msg)
 		} else if c.Kind == commentKind {
 			comment, err = parseComment(&c)
-			comments = append(comments, comment)
+			h.Comments = append(h.Comments, comment)
 		} else if c.Kind == postKind {
 			post, err = parsePost(&c)
-			posts = append(posts, post)
+			h.Posts = append(h.Posts, post)
 		} else if c.Kind == moreKind {
 			more, err = parseMore(&c)
-			mores = append(mores, more)
+			h.Mores = append(h.Mores, more)
+		} else if c.Kind == modActionKind {
+			action, err = parseModAction(&c)
+			h.ModActions = append(h.ModActions, action)
+		} else if c.Kind == subredditKind {
+			sub, err = parseSubreddit(&c)
+			h.Subreddits = append(h.Subreddits, sub)
+		} else if c.Kind == redditorKind {
+			user, err = parseRedditor(&c)
+			h.Redditors = append(h.Redditors, user)
 		}
 	}
 
-	return comments, posts, msgs, mores, err
+	return h, err
 }
 
 // parseComment parses a comment into the user facing Comment struct.
@@ -276,14 +359,17 @@ func parseComment(t *thing) (*Comment, error) {
 	}
 
 	var err error
-	var mores []*More
 	if c.Replies.Kind == listingKind {
-		c.Comment.Replies, _, _, mores, err = parseListing(&c.Replies)
+		var replies Harvest
+		replies, err = parseListing(&c.Replies)
+		c.Comment.Replies = replies.Comments
 		// a commment branch should only have one more object
-		if len(mores) == 1 {
-			c.Comment.More = mores[0]
-		} else if len(mores) > 1 {
-			return nil, fmt.Errorf("expected 1 more; found %d", len(mores))
+		if len(replies.Mores) == 1 {
+			c.Comment.More = replies.Mores[0]
+		} else if len(replies.Mores) > 1 {
+			return nil, fmt.Errorf(
+				"expected 1 more; found %d", len(replies.Mores),
+			)
 		}
 	}
 
@@ -320,6 +406,62 @@ func parseMore(t *thing) (*More, error) {
 	return m, nil
 }
 
+// parseModAction parses a moderation log entry into the user facing ModAction
+// struct.
+func parseModAction(t *thing) (*ModAction, error) {
+	a := &ModAction{}
+	if err := mapstructure.Decode(t.Data, a); err != nil {
+		return nil, mapDecodeError(err, t.Data)
+	}
+
+	a.Name = a.ID
+	return a, nil
+}
+
+// parseSubreddit parses a subreddit into the user facing Subreddit struct.
+func parseSubreddit(t *thing) (*Subreddit, error) {
+	s := &Subreddit{}
+	if err := mapstructure.Decode(t.Data, s); err != nil {
+		return nil, mapDecodeError(err, t.Data)
+	}
+
+	return s, nil
+}
+
+// parseRedditor parses a user account into the user facing Redditor struct.
+func parseRedditor(t *thing) (*Redditor, error) {
+	r := &Redditor{}
+	if err := mapstructure.Decode(t.Data, r); err != nil {
+		return nil, mapDecodeError(err, t.Data)
+	}
+
+	return r, nil
+}
+
+// parseRule parses a subreddit rule into the user facing Rule struct.
+func parseRule(data map[string]interface{}) (*Rule, error) {
+	r := &Rule{}
+	if err := mapstructure.Decode(data, r); err != nil {
+		return nil, mapDecodeError(err, data)
+	}
+
+	return r, nil
+}
+
+// parseRelationship parses an entry in one of a subreddit's lists of users.
+func parseRelationship(data map[string]interface{}) (*Relationship, error) {
+	r := &Relationship{}
+	if err := mapstructure.Decode(data, r); err != nil {
+		return nil, mapDecodeError(err, data)
+	}
+
+	// Reddit reports no days left for permanent bans.
+	if days, ok := data["days_left"]; ok && days == nil {
+		r.Permanent = true
+	}
+	return r, nil
+}
+
 func mapDecodeError(err error, val interface{}) error {
 	return fmt.Errorf(
 		"failed to decode json map into struct: %v; value: %v",
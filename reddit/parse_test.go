@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/kylelemons/godebug/pretty"
 	"github.com/turnage/graw/reddit/internal/testdata"
 )
 
@@ -23,7 +24,7 @@ func TestParse(t *testing.T) {
 		testdata.MustAsset("inbox.json"),
 		testdata.MustAsset("more.json"),
 	} {
-		if _, _, _, _, err := p.parse(input); err != nil {
+		if _, err := p.parse(input); err != nil {
 			t.Errorf("failed to parse input %d: %v", i, err)
 		}
 	}
@@ -111,13 +112,13 @@ func TestParseThread(t *testing.T) {
 }
 
 func TestParseUserFeed(t *testing.T) {
-	comments, posts, _, _, err := parseRawListing(
-		testdata.MustAsset("user.json"),
-	)
+	h, err := parseRawListing(testdata.MustAsset("user.json"))
 	if err != nil {
 		t.Fatalf("failed to parse user feed: %v", err)
 	}
 
+	comments, posts := h.Comments, h.Posts
+
 	if len(comments) < 1 {
 		t.Fatalf("found no comments in user feed")
 	}
@@ -142,11 +143,13 @@ func TestParseUserFeed(t *testing.T) {
 }
 
 func TestParseSubredditFeed(t *testing.T) {
-	_, posts, _, _, err := parseRawListing(testdata.MustAsset("subreddit.json"))
+	h, err := parseRawListing(testdata.MustAsset("subreddit.json"))
 	if err != nil {
 		t.Fatalf("failed to parse subreddit feed: %v", err)
 	}
 
+	posts := h.Posts
+
 	if len(posts) != 27 {
 		t.Fatalf(
 			"failed to parse all posts; found %d; wanted %d",
@@ -167,11 +170,13 @@ func TestParseSubredditFeed(t *testing.T) {
 }
 
 func TestParseInboxFeed(t *testing.T) {
-	_, _, msgs, _, err := parseRawListing(testdata.MustAsset("inbox.json"))
+	h, err := parseRawListing(testdata.MustAsset("inbox.json"))
 	if err != nil {
 		t.Fatalf("failed to parse inbox feed: %v", err)
 	}
 
+	msgs := h.Messages
+
 	if len(msgs) != 5 {
 		t.Fatalf("found unexpected number of messages: %v", len(msgs))
 	}
@@ -195,3 +200,123 @@ func TestParseMoreChildren(t *testing.T) {
 		t.Fatalf("found unexpected number of mores: %v", len(mores))
 	}
 }
+
+func TestParseErrors(t *testing.T) {
+	p := newParser()
+	for i, test := range []struct {
+		blob string
+		code string
+	}{
+		{``, ""},
+		{`{}`, ""},
+		{`{"json": {"errors": []}}`, ""},
+		{
+			`{"json": {"errors": [["RATELIMIT", "you are doing that too much. try again in 1 minute.", "ratelimit"]]}}`,
+			"RATELIMIT",
+		},
+	} {
+		err := p.parse_errors([]byte(test.blob))
+		if test.code == "" {
+			if err != nil {
+				t.Errorf("%d: unexpected error: %v", i, err)
+			}
+			continue
+		}
+
+		if apiErr, ok := err.(*APIError); !ok || apiErr.Code != test.code {
+			t.Errorf("%d: wanted %s error; got %v", i, test.code, err)
+		}
+	}
+}
+
+func TestParseEdited(t *testing.T) {
+	submission, err := newParser().parse_submitted([]byte(`{"json": {"errors": [], "data": {"things": [{"kind": "t1", "data": {"id": "abc", "name": "t1_abc", "permalink": "/r/sub/comments/x/y/abc/", "body": "text"}}]}}}`))
+	if err != nil {
+		t.Fatalf("failed to parse edited comment: %v", err)
+	}
+
+	expected := Submission{
+		ID:   "abc",
+		Name: "t1_abc",
+		URL:  "https://reddit.com/r/sub/comments/x/y/abc/",
+	}
+	if submission != expected {
+		t.Errorf("got %+v; wanted %+v", submission, expected)
+	}
+}
+
+func TestParseModLog(t *testing.T) {
+	h, err := parseRawListing([]byte(`{"kind": "Listing", "data": {"children": [{"kind": "modaction", "data": {"id": "ModAction_1", "action": "removelink", "mod": "moddy", "target_fullname": "t3_abc", "target_author": "spammer", "details": "remove", "description": "off topic", "subreddit": "sub", "created_utc": 1500000000.0}}]}}`))
+	if err != nil {
+		t.Fatalf("failed to parse mod log: %v", err)
+	}
+
+	expected := []*ModAction{
+		&ModAction{
+			ID:             "ModAction_1",
+			Name:           "ModAction_1",
+			CreatedUTC:     1500000000,
+			Action:         "removelink",
+			Mod:            "moddy",
+			TargetFullname: "t3_abc",
+			TargetAuthor:   "spammer",
+			Details:        "remove",
+			Description:    "off topic",
+			Subreddit:      "sub",
+		},
+	}
+	if diff := pretty.Compare(h.ModActions, expected); diff != "" {
+		t.Errorf("mod log parsed incorrectly; diff: %s", diff)
+	}
+}
+
+func TestParseSubreddit(t *testing.T) {
+	h, err := newParser().parse([]byte(`{"kind": "t5", "data": {"id": "2rc7j", "name": "t5_2rc7j", "display_name": "golang", "title": "The Go Programming Language", "subscribers": 200000, "active_user_count": null, "created_utc": 1257442478.0, "subreddit_type": "public", "submission_type": "any", "over18": false, "user_is_moderator": null}}`))
+	if err != nil {
+		t.Fatalf("failed to parse subreddit: %v", err)
+	}
+
+	expected := []*Subreddit{
+		&Subreddit{
+			ID:             "2rc7j",
+			Name:           "t5_2rc7j",
+			DisplayName:    "golang",
+			Title:          "The Go Programming Language",
+			Subscribers:    200000,
+			CreatedUTC:     1257442478,
+			SubredditType:  "public",
+			SubmissionType: "any",
+		},
+	}
+	if diff := pretty.Compare(h.Subreddits, expected); diff != "" {
+		t.Errorf("subreddit parsed incorrectly; diff: %s", diff)
+	}
+
+	if _, err := newParser().parse(
+		[]byte(`{"kind": "t9", "data": {}}`),
+	); err == nil {
+		t.Errorf("wanted an error for a thing of unknown kind")
+	}
+}
+
+func TestParseRedditor(t *testing.T) {
+	h, err := newParser().parse([]byte(`{"kind": "t2", "data": {"id": "abc", "name": "gopher", "created_utc": 1500000000.0, "link_karma": 10, "comment_karma": 20, "total_karma": 30, "has_verified_email": true, "is_suspended": false}}`))
+	if err != nil {
+		t.Fatalf("failed to parse redditor: %v", err)
+	}
+
+	expected := []*Redditor{
+		&Redditor{
+			ID:               "abc",
+			Name:             "gopher",
+			CreatedUTC:       1500000000,
+			LinkKarma:        10,
+			CommentKarma:     20,
+			TotalKarma:       30,
+			HasVerifiedEmail: true,
+		},
+	}
+	if diff := pretty.Compare(h.Redditors, expected); diff != "" {
+		t.Errorf("redditor parsed incorrectly; diff: %s", diff)
+	}
+}
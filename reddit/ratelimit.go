@@ -0,0 +1,68 @@
+package reddit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit is Reddit's account of the requests a client has left in the
+// current rate limit window, as reported in the X-Ratelimit headers of its
+// responses.
+type RateLimit struct {
+	// Remaining is the number of requests left in the window.
+	Remaining float64
+	// Used is the number of requests made in the window.
+	Used int
+	// Reset is when the window ends and the budget is replenished.
+	Reset time.Time
+}
+
+// RateLimited defines access to the rate limit budget of a Reddit handle.
+type RateLimited interface {
+	// RateLimit returns the rate limit budget Reddit reported in its most
+	// recent response. It returns false if Reddit has not reported one.
+	// Several processes using the same account share this budget.
+	RateLimit() (RateLimit, bool)
+}
+
+// parseRateLimit reads a rate limit budget from the headers of a response,
+// returning false if any of them are missing or malformed.
+func parseRateLimit(h http.Header, now time.Time) (RateLimit, bool) {
+	remaining, err := strconv.ParseFloat(h.Get("X-Ratelimit-Remaining"), 64)
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	used, err := strconv.Atoi(h.Get("X-Ratelimit-Used"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	reset, err := strconv.Atoi(h.Get("X-Ratelimit-Reset"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	return RateLimit{
+		Remaining: remaining,
+		Used:      used,
+		Reset:     now.Add(time.Duration(reset) * time.Second),
+	}, true
+}
+
+// interval returns the time between requests which spreads the remaining
+// requests evenly over the rest of the window. If the budget is spent it is the
+// time left in the window.
+func (r RateLimit) interval(now time.Time) time.Duration {
+	left := r.Reset.Sub(now)
+	if left <= 0 {
+		return 0
+	}
+
+	if r.Remaining < 1 {
+		return left
+	}
+
+	return time.Duration(float64(left) / r.Remaining)
+}
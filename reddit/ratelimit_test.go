@@ -0,0 +1,84 @@
+package reddit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Now()
+	for i, test := range []struct {
+		remaining, used, reset string
+		ok                     bool
+		limit                  RateLimit
+	}{
+		{"598.0", "2", "30", true, RateLimit{598, 2, now.Add(30 * time.Second)}},
+		{"", "2", "30", false, RateLimit{}},
+		{"598.0", "x", "30", false, RateLimit{}},
+		{"598.0", "2", "", false, RateLimit{}},
+	} {
+		h := http.Header{}
+		h.Set("X-Ratelimit-Remaining", test.remaining)
+		h.Set("X-Ratelimit-Used", test.used)
+		h.Set("X-Ratelimit-Reset", test.reset)
+
+		limit, ok := parseRateLimit(h, now)
+		if ok != test.ok {
+			t.Errorf("%d: got ok %v; wanted %v", i, ok, test.ok)
+		} else if limit != test.limit {
+			t.Errorf("%d: got %v; wanted %v", i, limit, test.limit)
+		}
+	}
+}
+
+func TestRateLimitInterval(t *testing.T) {
+	now := time.Now()
+	for i, test := range []struct {
+		limit    RateLimit
+		interval time.Duration
+	}{
+		{RateLimit{Remaining: 10, Reset: now.Add(10 * time.Second)}, time.Second},
+		{RateLimit{Remaining: 0, Reset: now.Add(10 * time.Second)}, 10 * time.Second},
+		{RateLimit{Remaining: 0, Reset: now.Add(-time.Second)}, 0},
+	} {
+		if interval := test.limit.interval(now); interval != test.interval {
+			t.Errorf("%d: got %v; wanted %v", i, interval, test.interval)
+		}
+	}
+}
+
+func TestDoRecordsRateLimit(t *testing.T) {
+	serv := serverWhich(nil, http.StatusOK)
+	defer serv.Close()
+
+	b := &baseClient{cli: &http.Client{}}
+	if _, ok := b.RateLimit(); ok {
+		t.Errorf("wanted no rate limit before any responses")
+	}
+
+	serv.Config.Handler = http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Ratelimit-Remaining", "10.0")
+			w.Header().Set("X-Ratelimit-Used", "590")
+			w.Header().Set("X-Ratelimit-Reset", "60")
+		},
+	)
+	req, err := http.NewRequest("GET", serv.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to prepare request for test: %v", err)
+	}
+
+	if _, err := b.Do(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	limit, ok := b.RateLimit()
+	if !ok {
+		t.Fatalf("wanted rate limit recorded")
+	}
+
+	if limit.Remaining != 10 || limit.Used != 590 {
+		t.Errorf("rate limit incorrect: %v", limit)
+	}
+}
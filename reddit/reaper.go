@@ -1,6 +1,10 @@
 package reddit
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -26,18 +30,28 @@ type reaperConfig struct {
 	reapSuffix string
 	tls        bool
 	rate       time.Duration
+	retry      RetryPolicy
 }
 
-// reaper is a high level api for Reddit HTTP requests.
+// reaper is a high level api for Reddit HTTP requests. Requests are bound to
+// the given context, including the wait for the rate limit.
 type reaper interface {
 	// reap executes a GET request to Reddit and returns the elements from
 	// the endpoint.
-	reap(path string, values map[string]string) (Harvest, error)
-	// sow executes a POST request to Reddit.
-	sow(path string, values map[string]string) error
+	reap(ctx context.Context, path string, values map[string]string) (Harvest, error)
+	// sow executes a POST request to Reddit, and returns any errors Reddit
+	// reports in the response.
+	sow(ctx context.Context, path string, values map[string]string) error
 	// get_sow executes a POST request to Reddit
 	// and returns the response, usually the posted item
-	get_sow(path string, values map[string]string) (Submission, error)
+	get_sow(ctx context.Context, path string, values map[string]string) (Submission, error)
+	// reap_json executes a GET request to Reddit and decodes the JSON
+	// response into v, for endpoints which do not respond with listings.
+	reap_json(ctx context.Context, path string, values map[string]string, v interface{}) error
+	// sow_json executes a request with the given method (e.g. POST or
+	// DELETE) and the values as a form body, and decodes the JSON response
+	// into v unless v is nil.
+	sow_json(ctx context.Context, method, path string, values map[string]string, v interface{}) error
 }
 
 type reaperImpl struct {
@@ -47,6 +61,7 @@ type reaperImpl struct {
 	reapSuffix string
 	scheme     string
 	rate       time.Duration
+	retry      RetryPolicy
 	last       time.Time
 	mu         *sync.Mutex
 }
@@ -59,57 +74,53 @@ func newReaper(c reaperConfig) reaper {
 		reapSuffix: c.reapSuffix,
 		scheme:     scheme[c.tls],
 		rate:       c.rate,
+		retry:      c.retry,
 		mu:         &sync.Mutex{},
 	}
 }
 
-func (r *reaperImpl) reap(path string, values map[string]string) (Harvest, error) {
-	r.rateBlock()
-	resp, err := r.cli.Do(
-		&http.Request{
+func (r *reaperImpl) reap(
+	ctx context.Context,
+	path string,
+	values map[string]string,
+) (Harvest, error) {
+	resp, err := r.do(ctx, func() *http.Request {
+		return &http.Request{
 			Method: "GET",
 			URL:    r.url(r.path(path, r.reapSuffix), values),
 			Host:   r.hostname,
-		},
-	)
+		}
+	})
 	if err != nil {
 		return Harvest{}, err
 	}
 
-	comments, posts, messages, mores, err := r.parser.parse(resp)
-	return Harvest{
-		Comments: comments,
-		Posts:    posts,
-		Messages: messages,
-		Mores:    mores,
-	}, err
+	return r.parser.parse(resp)
 }
 
-func (r *reaperImpl) sow(path string, values map[string]string) error {
-	r.rateBlock()
-	_, err := r.cli.Do(
-		&http.Request{
-			Method: "POST",
-			Header: formEncoding,
-			Host:   r.hostname,
-			URL:    r.url(path, values),
-		},
-	)
+func (r *reaperImpl) sow(
+	ctx context.Context,
+	path string,
+	values map[string]string,
+) error {
+	resp, err := r.do(ctx, func() *http.Request {
+		return r.post(path, jsonValues(values))
+	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	return r.parser.parse_errors(resp)
 }
 
-func (r *reaperImpl) get_sow(path string, values map[string]string) (Submission, error) {
-	r.rateBlock()
-	values["api_type"] = "json"
-	resp, err := r.cli.Do(
-		&http.Request{
-			Method: "POST",
-			Header: formEncoding,
-			Host:   r.hostname,
-			URL:    r.url(path, values),
-		},
-	)
+func (r *reaperImpl) get_sow(
+	ctx context.Context,
+	path string,
+	values map[string]string,
+) (Submission, error) {
+	resp, err := r.do(ctx, func() *http.Request {
+		return r.post(path, jsonValues(values))
+	})
 
 	if err != nil {
 		return Submission{}, err
@@ -118,14 +129,118 @@ func (r *reaperImpl) get_sow(path string, values map[string]string) (Submission,
 	return r.parser.parse_submitted(resp)
 }
 
-func (r *reaperImpl) rateBlock() {
+func (r *reaperImpl) reap_json(
+	ctx context.Context,
+	path string,
+	values map[string]string,
+	v interface{},
+) error {
+	resp, err := r.do(ctx, func() *http.Request {
+		return &http.Request{
+			Method: "GET",
+			URL:    r.url(r.path(path, r.reapSuffix), values),
+			Host:   r.hostname,
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resp, v)
+}
+
+func (r *reaperImpl) sow_json(
+	ctx context.Context,
+	method, path string,
+	values map[string]string,
+	v interface{},
+) error {
+	resp, err := r.do(ctx, func() *http.Request {
+		return r.form(method, path, values)
+	})
+	if err != nil || v == nil {
+		return err
+	}
+
+	return json.Unmarshal(resp, v)
+}
+
+// do executes the request built by req, bound to the context, once the rate
+// limit allows. Requests which fail are retried according to the reaper's
+// retry policy; req is called for every attempt.
+func (r *reaperImpl) do(
+	ctx context.Context,
+	req func() *http.Request,
+) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		if err := r.rateBlock(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := r.cli.Do(req().WithContext(ctx))
+		if err == nil {
+			return resp, nil
+		}
+
+		if attempt >= r.retry.MaxAttempts || !r.retry.retryable(err) {
+			return nil, err
+		}
+
+		delay := r.retry.backoff(attempt, retryAfter(err))
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rateBlock blocks until the reaper may make its next request, or until the
+// context is done. Requests are spaced by the configured rate, or further apart
+// if that is needed to stay within the rate limit budget Reddit last reported
+// for the rest of its window. Each caller reserves the next free slot in the
+// schedule, so callers are not serialized behind each other's waits; a slot
+// reserved by a caller whose context is done goes unused.
+func (r *reaperImpl) rateBlock(ctx context.Context) error {
+	now := time.Now()
+	interval := r.rate
+	if limit, ok := r.cli.RateLimit(); ok {
+		interval = maxOf(interval, limit.interval(now))
+	}
+
 	r.mu.Lock()
-	defer r.mu.Unlock()
+	slot := r.last.Add(interval)
+	if slot.Before(now) {
+		slot = now
+	}
+	r.last = slot
+	r.mu.Unlock()
 
-	if time.Since(r.last) < r.rate {
-		<-time.After(r.last.Add(r.rate).Sub(time.Now()))
+	return sleep(ctx, time.Until(slot))
+}
+
+// post returns a POST request to the path with the values encoded as a form
+// in its body.
+func (r *reaperImpl) post(path string, values map[string]string) *http.Request {
+	return r.form("POST", path, values)
+}
+
+// form returns a request with the given method to the path with the values
+// encoded as a form in its body.
+func (r *reaperImpl) form(
+	method, path string,
+	values map[string]string,
+) *http.Request {
+	body := r.formatValues(values).Encode()
+	return &http.Request{
+		Method:        method,
+		Header:        formEncoding,
+		Host:          r.hostname,
+		URL:           r.url(path, nil),
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		GetBody: func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(body)), nil
+		},
 	}
-	r.last = time.Now()
 }
 
 func (r *reaperImpl) url(path string, values map[string]string) *url.URL {
@@ -145,6 +260,16 @@ func (r *reaperImpl) path(p string, suff string) string {
 	return p + suff
 }
 
+// jsonValues returns a copy of the values which asks Reddit to respond in JSON,
+// so that errors are reported in the response body rather than swallowed.
+func jsonValues(values map[string]string) map[string]string {
+	result := map[string]string{"api_type": "json"}
+	for key, value := range values {
+		result[key] = value
+	}
+	return result
+}
+
 func (r *reaperImpl) formatValues(values map[string]string) url.Values {
 	formattedValues := url.Values{}
 
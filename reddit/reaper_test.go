@@ -1,8 +1,11 @@
 package reddit
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -95,7 +98,7 @@ func TestReap(t *testing.T) {
 			mu:       &sync.Mutex{},
 		}
 
-		Harvest, err := r.reap(test.path, test.values)
+		Harvest, err := r.reap(context.Background(), test.path, test.values)
 		if err != nil {
 			t.Errorf("Error reaping input %d: %v", i, err)
 		}
@@ -104,7 +107,7 @@ func TestReap(t *testing.T) {
 			t.Errorf("Harvest incorrect; diff: %s", diff)
 		}
 
-		if diff := pretty.Compare(c.request, test.correct); diff != "" {
+		if diff := pretty.Compare(c.request, withBackground(test.correct)); diff != "" {
 			t.Errorf("request incorrect; diff: %s", diff)
 		}
 	}
@@ -115,40 +118,38 @@ func TestSow(t *testing.T) {
 		path    string
 		values  map[string]string
 		correct http.Request
+		body    string
 	}{
 		{"", nil, http.Request{
 			Method: "POST",
 			Header: formEncoding,
 			Host:   "com",
 			URL: &url.URL{
-				Scheme:   "http",
-				Host:     "com",
-				Path:     "",
-				RawQuery: "",
+				Scheme: "http",
+				Host:   "com",
+				Path:   "",
 			},
-		}},
+		}, "api_type=json"},
 		{"", map[string]string{"key": "value"}, http.Request{
 			Method: "POST",
 			Header: formEncoding,
 			Host:   "com",
 			URL: &url.URL{
-				Scheme:   "http",
-				Host:     "com",
-				Path:     "",
-				RawQuery: "key=value",
+				Scheme: "http",
+				Host:   "com",
+				Path:   "",
 			},
-		}},
+		}, "api_type=json&key=value"},
 		{"path", nil, http.Request{
 			Method: "POST",
 			Header: formEncoding,
 			Host:   "com",
 			URL: &url.URL{
-				Scheme:   "http",
-				Host:     "com",
-				Path:     "path",
-				RawQuery: "",
+				Scheme: "http",
+				Host:   "com",
+				Path:   "path",
 			},
-		}},
+		}, "api_type=json"},
 	} {
 		c := &mockClient{}
 		r := &reaperImpl{
@@ -159,22 +160,93 @@ func TestSow(t *testing.T) {
 			mu:       &sync.Mutex{},
 		}
 
-		if err := r.sow(test.path, test.values); err != nil {
+		if err := r.sow(context.Background(), test.path, test.values); err != nil {
 			t.Errorf("Error reaping input %d: %v", i, err)
 		}
 
-		if diff := pretty.Compare(c.request, test.correct); diff != "" {
+		test.correct.ContentLength = int64(len(test.body))
+		if diff := pretty.Compare(c.request, withBackground(test.correct)); diff != "" {
 			t.Errorf("request incorrect; diff: %s", diff)
 		}
+
+		if c.body != test.body {
+			t.Errorf("body incorrect; got %s; wanted %s", c.body, test.body)
+		}
+	}
+}
+
+func TestSowLargeBody(t *testing.T) {
+	// Reddit accepts comments up to 10,000 characters, which would make
+	// for a very long URL.
+	text := strings.Repeat("words & symbols = ", 600)
+
+	var received url.Values
+	var query string
+	serv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			query = req.URL.RawQuery
+			if err := req.ParseForm(); err != nil {
+				t.Errorf("failed to parse form: %v", err)
+			}
+			received = req.PostForm
+			w.Write([]byte(`{"json": {"errors": [], "data": {"name": "t1_x"}}}`))
+		},
+	))
+	defer serv.Close()
+
+	servURL, err := url.Parse(serv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server url: %v", err)
+	}
+
+	r := &reaperImpl{
+		cli:      &baseClient{cli: &http.Client{}},
+		parser:   newParser(),
+		hostname: servURL.Host,
+		scheme:   "http",
+		mu:       &sync.Mutex{},
+	}
+
+	for _, sow := range []func() error{
+		func() error {
+			return r.sow(context.Background(), "/api/comment", map[string]string{
+				"thing_id": "t1_name",
+				"text":     text,
+			})
+		},
+		func() error {
+			_, err := r.get_sow(context.Background(), "/api/comment", map[string]string{
+				"thing_id": "t1_name",
+				"text":     text,
+			})
+			return err
+		},
+	} {
+		received, query = nil, ""
+		if err := sow(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if query != "" {
+			t.Errorf("wanted no values in url; got %s", query)
+		}
+
+		if received.Get("text") != text || received.Get("thing_id") != "t1_name" {
+			t.Errorf("form values incorrect: %v", received)
+		}
+
+		if received.Get("api_type") != "json" {
+			t.Errorf("wanted api_type in form; got %v", received)
+		}
 	}
 }
 
 func TestRateBlockReap(t *testing.T) {
-	testRateBlock(func(r reaper) { r.reap("", nil) }, t)
+	testRateBlock(func(r reaper) { r.reap(context.Background(), "", nil) }, t)
 }
 
 func TestRateBlockSow(t *testing.T) {
-	testRateBlock(func(r reaper) { r.sow("", nil) }, t)
+	testRateBlock(func(r reaper) { r.sow(context.Background(), "", nil) }, t)
 }
 
 func testRateBlock(f func(reaper), t *testing.T) {
@@ -196,3 +268,63 @@ func testRateBlock(f func(reaper), t *testing.T) {
 		t.Errorf("wanted updated timestamp; found same timestamp")
 	}
 }
+
+func TestRateBlockCancel(t *testing.T) {
+	r := &reaperImpl{
+		cli:    &mockClient{},
+		parser: &mockParser{},
+		rate:   time.Hour,
+		last:   time.Now(),
+		mu:     &sync.Mutex{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+
+	if _, err := r.reap(ctx, "", nil); err != context.Canceled {
+		t.Errorf("wanted canceled error; got %v", err)
+	}
+}
+
+// withBackground returns a copy of the request bound to the background
+// context, as the reaper binds requests made without a context.
+func withBackground(r http.Request) *http.Request {
+	return r.WithContext(context.Background())
+}
+
+func TestRateBlockAdaptive(t *testing.T) {
+	start := time.Now()
+	r := &reaperImpl{
+		cli: &mockClient{
+			limit: &RateLimit{
+				Remaining: 1,
+				Reset:     start.Add(20 * time.Millisecond),
+			},
+		},
+		parser: &mockParser{},
+		rate:   time.Millisecond,
+		last:   start,
+		mu:     &sync.Mutex{},
+	}
+
+	if _, err := r.reap(context.Background(), "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if block := time.Since(start); block < 15*time.Millisecond {
+		t.Errorf("wanted block paced by rate limit budget; blocked for %v", block)
+	}
+}
+
+func TestSowReportsErrors(t *testing.T) {
+	expected := &APIError{Code: "THREAD_LOCKED"}
+	r := &reaperImpl{
+		cli:    &mockClient{},
+		parser: &mockParser{err: expected},
+		mu:     &sync.Mutex{},
+	}
+
+	if err := r.sow(context.Background(), "", nil); err != expected {
+		t.Errorf("wanted error from response; got %v", err)
+	}
+}
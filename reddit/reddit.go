@@ -25,7 +25,9 @@
 //   bot, _ := NewBot(cfg)
 //   bot.SendMessage("roxven", "Thanks for making this Reddit API!", "It's ok.")
 //
-// Requests made by this API are rate limited with no bursting. All interfaces
+// Requests made by this API are rate limited with no bursting. Requests are
+// also paced to stay within the rate limit budget Reddit reports, which is
+// shared by all processes using an account; see RateLimited. All interfaces
 // exported by this package have goroutine safe implementations, but when shared
 // by many goroutines some calls may block for multiples of the rate limit
 // interval.
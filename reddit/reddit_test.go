@@ -1,7 +1,9 @@
 package reddit
 
 import (
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
@@ -16,6 +18,8 @@ type testCase struct {
 	err     error
 	f       func(Bot) error
 	correct http.Request
+	// body is the form the request should send in its body, if any.
+	body string
 }
 
 func TestAccount(t *testing.T) {
@@ -29,14 +33,14 @@ func TestAccount(t *testing.T) {
 				correct: http.Request{
 					Method: "POST",
 					URL: &url.URL{
-						Scheme:   "https",
-						Host:     "reddit.com",
-						Path:     "/api/comment",
-						RawQuery: "text=text&thing_id=name",
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/comment",
 					},
 					Host:   "reddit.com",
 					Header: formEncoding,
 				},
+				body: "api_type=json&text=text&thing_id=name",
 			},
 			testCase{
 				name: "GetReply",
@@ -47,14 +51,14 @@ func TestAccount(t *testing.T) {
 				correct: http.Request{
 					Method: "POST",
 					URL: &url.URL{
-						Scheme:   "https",
-						Host:     "reddit.com",
-						Path:     "/api/comment",
-						RawQuery: "api_type=json&text=text&thing_id=name",
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/comment",
 					},
 					Host:   "reddit.com",
 					Header: formEncoding,
 				},
+				body: "api_type=json&text=text&thing_id=name",
 			},
 			testCase{
 				name: "SendMessage",
@@ -64,14 +68,14 @@ func TestAccount(t *testing.T) {
 				correct: http.Request{
 					Method: "POST",
 					URL: &url.URL{
-						Scheme:   "https",
-						Host:     "reddit.com",
-						Path:     "/api/compose",
-						RawQuery: "subject=subject&text=text&to=user",
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/compose",
 					},
 					Host:   "reddit.com",
 					Header: formEncoding,
 				},
+				body: "api_type=json&subject=subject&text=text&to=user",
 			},
 			testCase{
 				name: "PostSelf",
@@ -81,14 +85,14 @@ func TestAccount(t *testing.T) {
 				correct: http.Request{
 					Method: "POST",
 					URL: &url.URL{
-						Scheme:   "https",
-						Host:     "reddit.com",
-						Path:     "/api/submit",
-						RawQuery: "kind=self&sr=self&text=text&title=title",
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/submit",
 					},
 					Host:   "reddit.com",
 					Header: formEncoding,
 				},
+				body: "api_type=json&kind=self&sr=self&text=text&title=title",
 			},
 			testCase{
 				name: "GetPostSelf",
@@ -100,14 +104,14 @@ func TestAccount(t *testing.T) {
 				correct: http.Request{
 					Method: "POST",
 					URL: &url.URL{
-						Scheme:   "https",
-						Host:     "reddit.com",
-						Path:     "/api/submit",
-						RawQuery: "api_type=json&kind=self&sr=self&text=text&title=title",
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/submit",
 					},
 					Host:   "reddit.com",
 					Header: formEncoding,
 				},
+				body: "api_type=json&kind=self&sr=self&text=text&title=title",
 			},
 			testCase{
 				name: "PostLink",
@@ -117,14 +121,14 @@ func TestAccount(t *testing.T) {
 				correct: http.Request{
 					Method: "POST",
 					URL: &url.URL{
-						Scheme:   "https",
-						Host:     "reddit.com",
-						Path:     "/api/submit",
-						RawQuery: "kind=link&sr=link&title=title&url=url",
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/submit",
 					},
 					Host:   "reddit.com",
 					Header: formEncoding,
 				},
+				body: "api_type=json&kind=link&sr=link&title=title&url=url",
 			},
 			testCase{
 				name: "GetPostLink",
@@ -135,19 +139,599 @@ func TestAccount(t *testing.T) {
 				correct: http.Request{
 					Method: "POST",
 					URL: &url.URL{
-						Scheme:   "https",
-						Host:     "reddit.com",
-						Path:     "/api/submit",
-						RawQuery: "api_type=json&kind=link&sr=link&title=title&url=url",
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/submit",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&kind=link&sr=link&title=title&url=url",
+			},
+			testCase{
+				name: "Upvote",
+				f: func(b Bot) error {
+					return b.Upvote("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/vote",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&dir=1&id=t3_name",
+			},
+			testCase{
+				name: "Downvote",
+				f: func(b Bot) error {
+					return b.Downvote("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/vote",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&dir=-1&id=t3_name",
+			},
+			testCase{
+				name: "ClearVote",
+				f: func(b Bot) error {
+					return b.ClearVote("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/vote",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&dir=0&id=t3_name",
+			},
+			testCase{
+				name: "Edit",
+				f: func(b Bot) error {
+					_, err := b.Edit("t1_name", "text")
+					return err
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/editusertext",
 					},
 					Host:   "reddit.com",
 					Header: formEncoding,
 				},
+				body: "api_type=json&text=text&thing_id=t1_name",
+			},
+			testCase{
+				name: "Delete",
+				f: func(b Bot) error {
+					return b.Delete("t1_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/del",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t1_name",
 			},
 		}, t,
 	)
 }
 
+func TestModerator(t *testing.T) {
+	testRequests(
+		[]testCase{
+			testCase{
+				name: "Approve",
+				f: func(b Bot) error {
+					return moderatorOf(b).Approve("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/approve",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name",
+			},
+			testCase{
+				name: "Remove",
+				f: func(b Bot) error {
+					return moderatorOf(b).Remove("t3_name", false)
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/remove",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name&spam=false",
+			},
+			testCase{
+				name: "RemoveSpam",
+				f: func(b Bot) error {
+					return moderatorOf(b).Remove("t3_name", true)
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/remove",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name&spam=true",
+			},
+			testCase{
+				name: "SetRemovalReason",
+				f: func(b Bot) error {
+					return moderatorOf(b).SetRemovalReason("t3_name", "reason", "note")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/v1/modactions/removal_reasons",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&json=%7B%22item_ids%22%3A%5B%22t3_name%22%5D%2C%22reason_id%22%3A%22reason%22%2C%22mod_note%22%3A%22note%22%7D",
+			},
+			testCase{
+				name: "Lock",
+				f: func(b Bot) error {
+					return moderatorOf(b).Lock("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/lock",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name",
+			},
+			testCase{
+				name: "Unlock",
+				f: func(b Bot) error {
+					return moderatorOf(b).Unlock("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/unlock",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name",
+			},
+			testCase{
+				name: "Sticky",
+				f: func(b Bot) error {
+					return moderatorOf(b).Sticky("t3_name", 2)
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/set_subreddit_sticky",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name&num=2&state=true",
+			},
+			testCase{
+				name: "Unsticky",
+				f: func(b Bot) error {
+					return moderatorOf(b).Unsticky("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/set_subreddit_sticky",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name&state=false",
+			},
+			testCase{
+				name: "Distinguish",
+				f: func(b Bot) error {
+					return moderatorOf(b).Distinguish("t1_name", true)
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/distinguish",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&how=yes&id=t1_name&sticky=true",
+			},
+			testCase{
+				name: "Undistinguish",
+				f: func(b Bot) error {
+					return moderatorOf(b).Undistinguish("t1_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/distinguish",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&how=no&id=t1_name",
+			},
+			testCase{
+				name: "MarkNSFW",
+				f: func(b Bot) error {
+					return moderatorOf(b).MarkNSFW("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/marknsfw",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name",
+			},
+			testCase{
+				name: "UnmarkNSFW",
+				f: func(b Bot) error {
+					return moderatorOf(b).UnmarkNSFW("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/unmarknsfw",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name",
+			},
+			testCase{
+				name: "MarkSpoiler",
+				f: func(b Bot) error {
+					return moderatorOf(b).MarkSpoiler("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/spoiler",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name",
+			},
+			testCase{
+				name: "UnmarkSpoiler",
+				f: func(b Bot) error {
+					return moderatorOf(b).UnmarkSpoiler("t3_name")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/unspoiler",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name",
+			},
+			testCase{
+				name: "SetContestMode",
+				f: func(b Bot) error {
+					return moderatorOf(b).SetContestMode("t3_name", true)
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/set_contest_mode",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name&state=true",
+			},
+			testCase{
+				name: "SetSuggestedSort",
+				f: func(b Bot) error {
+					return moderatorOf(b).SetSuggestedSort("t3_name", "new")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/set_suggested_sort",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name&sort=new",
+			},
+			testCase{
+				name: "ClearSuggestedSort",
+				f: func(b Bot) error {
+					return moderatorOf(b).SetSuggestedSort("t3_name", "")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/api/set_suggested_sort",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&id=t3_name&sort=blank",
+			},
+			testCase{
+				name: "Ban",
+				f: func(b Bot) error {
+					return moderatorOf(b).Ban("sub", "user", 3, "spam", "third strike", "bye")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/r/sub/api/friend",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&ban_message=bye&ban_reason=spam&duration=3&name=user&note=third+strike&type=banned",
+			},
+			testCase{
+				name: "BanPermanent",
+				f: func(b Bot) error {
+					return moderatorOf(b).Ban("sub", "user", 0, "", "", "")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/r/sub/api/friend",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&ban_message=&ban_reason=&name=user&note=&type=banned",
+			},
+			testCase{
+				name: "Unban",
+				f: func(b Bot) error {
+					return moderatorOf(b).Unban("sub", "user")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/r/sub/api/unfriend",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&name=user&type=banned",
+			},
+			testCase{
+				name: "Mute",
+				f: func(b Bot) error {
+					return moderatorOf(b).Mute("sub", "user", "note")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/r/sub/api/friend",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&name=user&note=note&type=muted",
+			},
+			testCase{
+				name: "AddContributor",
+				f: func(b Bot) error {
+					return moderatorOf(b).AddContributor("sub", "user")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/r/sub/api/friend",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&name=user&type=contributor",
+			},
+			testCase{
+				name: "InviteModerator",
+				f: func(b Bot) error {
+					return moderatorOf(b).InviteModerator("sub", "user", "posts", "wiki")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/r/sub/api/friend",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&name=user&permissions=%2Bposts%2C%2Bwiki&type=moderator_invite",
+			},
+			testCase{
+				name: "InviteModeratorAll",
+				f: func(b Bot) error {
+					return moderatorOf(b).InviteModerator("sub", "user")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/r/sub/api/friend",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&name=user&permissions=%2Ball&type=moderator_invite",
+			},
+			testCase{
+				name: "RemoveModerator",
+				f: func(b Bot) error {
+					return moderatorOf(b).RemoveModerator("sub", "user")
+				},
+				correct: http.Request{
+					Method: "POST",
+					URL: &url.URL{
+						Scheme: "https",
+						Host:   "reddit.com",
+						Path:   "/r/sub/api/unfriend",
+					},
+					Host:   "reddit.com",
+					Header: formEncoding,
+				},
+				body: "api_type=json&name=user&type=moderator",
+			},
+		}, t,
+	)
+}
+
+func TestRelationships(t *testing.T) {
+	r := &mockReaper{json: `{
+		"kind": "Listing",
+		"data": {
+			"after": null,
+			"children": [
+				{"name": "a", "id": "t2_a", "rel_id": "rb_1", "date": 1500000000.0, "note": "spam", "days_left": 3},
+				{"name": "b", "id": "t2_b", "rel_id": "rb_2", "date": 1500000001.0, "note": "", "days_left": null}
+			]
+		}
+	}`}
+	m := newModerator(r)
+
+	banned, err := m.Banned("sub")
+	if err != nil {
+		t.Fatalf("failed to read bans: %v", err)
+	}
+
+	if r.path != "/r/sub/about/banned" {
+		t.Errorf("wrong path: %s", r.path)
+	}
+
+	if diff := pretty.Compare(banned, []*Relationship{
+		{
+			ID:       "rb_1",
+			Name:     "a",
+			UserID:   "t2_a",
+			DateUTC:  1500000000,
+			Note:     "spam",
+			DaysLeft: 3,
+		},
+		{
+			ID:        "rb_2",
+			Name:      "b",
+			UserID:    "t2_b",
+			DateUTC:   1500000001,
+			Permanent: true,
+		},
+	}); diff != "" {
+		t.Errorf("bans incorrect; diff: %s", diff)
+	}
+
+	r.json = `{
+		"kind": "UserList",
+		"data": {
+			"children": [
+				{"name": "mod", "id": "t2_m", "date": 1500000000.0, "mod_permissions": ["posts", "wiki"]}
+			]
+		}
+	}`
+	mods, err := m.Moderators("sub")
+	if err != nil {
+		t.Fatalf("failed to read moderators: %v", err)
+	}
+
+	if r.path != "/r/sub/about/moderators" || len(mods) != 1 ||
+		pretty.Compare(mods[0].ModPermissions, []string{"posts", "wiki"}) != "" ||
+		mods[0].Permanent {
+		t.Errorf("wrong moderators from %s: %s", r.path, pretty.Sprint(mods))
+	}
+}
+
 func TestScanner(t *testing.T) {
 	testRequests(
 		[]testCase{
@@ -197,6 +781,47 @@ func TestLurker(t *testing.T) {
 	)
 }
 
+func TestVoteArchived(t *testing.T) {
+	serv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"fields": ["id"], "explanation": "that's a piece of history now", "message": "Bad Request", "reason": "TOO_OLD"}`))
+		},
+	))
+	defer serv.Close()
+
+	servURL, err := url.Parse(serv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server url: %v", err)
+	}
+
+	a := newAccount(&reaperImpl{
+		cli:      &baseClient{cli: &http.Client{}},
+		parser:   newParser(),
+		hostname: servURL.Host,
+		scheme:   "http",
+		mu:       &sync.Mutex{},
+	})
+
+	err = a.Upvote("t3_old")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("wanted APIError; got %v", err)
+	}
+
+	if !apiErr.Has("TOO_OLD") || apiErr.Field != "id" ||
+		apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("error details incorrect: %+v", apiErr)
+	}
+}
+
+// moderatorOf returns the moderator actions of a bot, which in tests are
+// always granted.
+func moderatorOf(b Bot) Moderator {
+	m, _ := b.Moderator()
+	return m
+}
+
 func testRequests(cases []testCase, t *testing.T) {
 	c := &mockClient{}
 	r := &reaperImpl{
@@ -208,21 +833,57 @@ func testRequests(cases []testCase, t *testing.T) {
 		mu:         &sync.Mutex{},
 	}
 	b := &bot{
-		Account: newAccount(r),
-		Lurker:  newLurker(r),
-		Scanner: newScanner(r),
+		Account:   newAccount(r),
+		Lurker:    newLurker(r),
+		Scanner:   newScanner(r),
+		moderator: newModerator(r),
+		modmail:   newModmail(r),
+		cli:       c,
 	}
 	for _, test := range cases {
+		test.correct.ContentLength = int64(len(test.body))
 		if err := test.f(b); err != test.err {
 			t.Errorf("[%s] unexpected error: %v", test.name, err)
 		}
 
-		if diff := pretty.Compare(c.request, test.correct); diff != "" {
+		if diff := pretty.Compare(c.request, withBackground(test.correct)); diff != "" {
 			t.Errorf(
 				"[%s] request incorrect; diff: %s",
 				test.name,
 				diff,
 			)
 		}
+
+		if c.body != test.body {
+			t.Errorf(
+				"[%s] body incorrect; got %s; wanted %s",
+				test.name,
+				c.body,
+				test.body,
+			)
+		}
+	}
+}
+
+func TestMe(t *testing.T) {
+	r := &mockReaper{json: `{"id": "abc", "name": "bot", "created_utc": 1500000000.0, "comment_karma": 5}`}
+	a := newAccount(r)
+
+	me, err := a.Me()
+	if err != nil {
+		t.Fatalf("failed to get account: %v", err)
+	}
+
+	if r.path != "/api/v1/me" {
+		t.Errorf("wrong path: %s", r.path)
+	}
+
+	if diff := pretty.Compare(me, &Redditor{
+		ID:           "abc",
+		Name:         "bot",
+		CreatedUTC:   1500000000,
+		CommentKarma: 5,
+	}); diff != "" {
+		t.Errorf("account incorrect; diff: %s", diff)
 	}
 }
@@ -0,0 +1,125 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"time"
+)
+
+// DefaultRetryPolicy retries requests which fail with transient errors a few
+// times over about half a minute.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   2 * time.Second,
+	MaxDelay:    time.Minute,
+}
+
+// RetryPolicy configures how requests that fail with transient errors, such as
+// BusyErr or a dropped connection, are retried. A policy with a MaxAttempts of
+// 0 is unset, and DefaultRetryPolicy is used in its place.
+//
+// Be aware that write requests are retried too. If Reddit acted on a request
+// but failed to respond (e.g. with GatewayTimeoutErr), a retry may act again.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is attempted,
+	// including the first. If 1, requests are not retried.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. The delay doubles
+	// with each retry after that, and a random jitter of up to half the
+	// delay is taken off so that clients which failed together do not
+	// retry together. If Reddit asks for a longer wait (see
+	// APIError.RetryAfter), that is used instead.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries. If 0, it is not capped.
+	MaxDelay time.Duration
+	// Retryable reports whether a request that failed with an error should
+	// be retried. If nil, IsTransient is used.
+	Retryable func(error) bool
+}
+
+// IsTransient reports whether an error returned by a request to Reddit is
+// likely to go away if the request is retried: Reddit being busy, rate limiting
+// or failing at its gateway, and network errors. Cancelled requests are not
+// transient.
+func IsTransient(err error) bool {
+	if errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	for _, transient := range []error{
+		BusyErr,
+		RateLimitErr,
+		GatewayErr,
+		GatewayTimeoutErr,
+	} {
+		if errors.Is(err, transient) {
+			return true
+		}
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// orDefault returns the policy, or DefaultRetryPolicy if it is unset.
+func (p RetryPolicy) orDefault() RetryPolicy {
+	if p.MaxAttempts == 0 {
+		return DefaultRetryPolicy
+	}
+	return p
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable == nil {
+		return IsTransient(err)
+	}
+	return p.Retryable(err)
+}
+
+// backoff returns the delay before the given retry (the first retry is 1). If
+// Reddit asked for a delay, it is used if it is longer.
+func (p RetryPolicy) backoff(retry int, asked time.Duration) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay == 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if delay > 0 {
+		delay -= time.Duration(rand.Int63n(int64(delay/2) + 1))
+	}
+
+	return maxOf(delay, asked)
+}
+
+// retryAfter returns the wait Reddit asked for in an error, if any.
+func retryAfter(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// sleep blocks for the duration, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,130 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyClient fails with the errors it is given before succeeding.
+type flakyClient struct {
+	mockClient
+	errs     []error
+	attempts int
+}
+
+func (f *flakyClient) Do(r *http.Request) ([]byte, error) {
+	f.attempts++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	return f.mockClient.Do(r)
+}
+
+func TestIsTransient(t *testing.T) {
+	for i, test := range []struct {
+		err       error
+		transient bool
+	}{
+		{BusyErr, true},
+		{RateLimitErr, true},
+		{GatewayErr, true},
+		{GatewayTimeoutErr, true},
+		{&net.OpError{Op: "dial", Err: fmt.Errorf("refused")}, true},
+		{PermissionDeniedErr, false},
+		{context.Canceled, false},
+		{fmt.Errorf("bad response code: 404"), false},
+	} {
+		if transient := IsTransient(test.err); transient != test.transient {
+			t.Errorf("%d: got %v; wanted %v", i, transient, test.transient)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 4 * time.Second, MaxDelay: 10 * time.Second}
+	for i, test := range []struct {
+		retry    int
+		asked    time.Duration
+		min, max time.Duration
+	}{
+		{1, 0, 2 * time.Second, 4 * time.Second},
+		{2, 0, 4 * time.Second, 8 * time.Second},
+		{3, 0, 5 * time.Second, 10 * time.Second},
+		{1, time.Minute, time.Minute, time.Minute},
+	} {
+		if d := p.backoff(test.retry, test.asked); d < test.min || d > test.max {
+			t.Errorf("%d: got %v; wanted in [%v, %v]", i, d, test.min, test.max)
+		}
+	}
+}
+
+func TestRetryPolicyDefault(t *testing.T) {
+	if p := (RetryPolicy{}).orDefault(); p.MaxAttempts != DefaultRetryPolicy.MaxAttempts {
+		t.Errorf("wanted the default policy for an unset one; got %+v", p)
+	}
+
+	if p := (RetryPolicy{MaxAttempts: 1}).orDefault(); p.MaxAttempts != 1 {
+		t.Errorf("wanted retries left disabled; got %+v", p)
+	}
+}
+
+func TestReaperRetries(t *testing.T) {
+	for i, test := range []struct {
+		errs     []error
+		attempts int
+		err      error
+	}{
+		{[]error{BusyErr, GatewayErr}, 3, nil},
+		{[]error{BusyErr, BusyErr, BusyErr}, 3, BusyErr},
+		{[]error{PermissionDeniedErr}, 1, PermissionDeniedErr},
+		{
+			[]error{&APIError{StatusCode: 429, RetryAfter: time.Millisecond}},
+			2, nil,
+		},
+	} {
+		c := &flakyClient{errs: test.errs}
+		r := &reaperImpl{
+			cli:    c,
+			parser: &mockParser{},
+			retry:  RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			mu:     &sync.Mutex{},
+		}
+
+		if _, err := r.reap(context.Background(), "", nil); err != test.err {
+			t.Errorf("%d: got error %v; wanted %v", i, err, test.err)
+		}
+
+		if c.attempts != test.attempts {
+			t.Errorf("%d: got %d attempts; wanted %d", i, c.attempts, test.attempts)
+		}
+	}
+}
+
+func TestDoRetryAfter(t *testing.T) {
+	serv := serverWhich(nil, http.StatusTooManyRequests)
+	defer serv.Close()
+	serv.Config.Handler = http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+	)
+
+	req, err := http.NewRequest("GET", serv.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to prepare request for test: %v", err)
+	}
+
+	_, err = (&baseClient{cli: &http.Client{}}).Do(req)
+	if !errors.Is(err, RateLimitErr) || retryAfter(err) != 7*time.Second {
+		t.Errorf("got %v after %v; wanted RateLimitErr after 7s", err, retryAfter(err))
+	}
+}
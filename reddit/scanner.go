@@ -1,5 +1,9 @@
 package reddit
 
+import (
+	"context"
+)
+
 // deletedAuthor is the author field of deleted posts on Reddit.
 const deletedAuthor = "[deleted]"
 
@@ -27,7 +31,17 @@ type Scanner interface {
 	// If you want a stream where all of this is handled for you, see graw
 	// or graw/streams.
 	Listing(path, after string) (Harvest, error)
+	// ListingContext is like Listing, but binds its request, including the
+	// wait for the rate limit, to the given context.
+	ListingContext(ctx context.Context, path, after string) (Harvest, error)
+	// ListingWithParams is like Listing, but the parameters of the request
+	// are given instead of a reference point.
 	ListingWithParams(path string, params map[string]string) (Harvest, error)
+	ListingWithParamsContext(
+		ctx context.Context,
+		path string,
+		params map[string]string,
+	) (Harvest, error)
 }
 
 type scanner struct {
@@ -39,8 +53,15 @@ func newScanner(r reaper) Scanner {
 }
 
 func (s *scanner) Listing(path, after string) (Harvest, error) {
+	return s.ListingContext(context.Background(), path, after)
+}
+
+func (s *scanner) ListingContext(
+	ctx context.Context,
+	path, after string,
+) (Harvest, error) {
 	return s.r.reap(
-		path, map[string]string{
+		ctx, path, map[string]string{
 			"raw_json": "1",
 			"limit":    "100",
 			"before":   after,
@@ -52,6 +73,14 @@ func (s *scanner) ListingWithParams(path string, params map[string]string) (
 	Harvest,
 	error,
 ) {
+	return s.ListingWithParamsContext(context.Background(), path, params)
+}
+
+func (s *scanner) ListingWithParamsContext(
+	ctx context.Context,
+	path string,
+	params map[string]string,
+) (Harvest, error) {
 	reaperParams := map[string]string{
 		"raw_json": "1",
 		"limit":    "100",
@@ -59,5 +88,5 @@ func (s *scanner) ListingWithParams(path string, params map[string]string) (
 	for key, value := range params {
 		reaperParams[key] = value
 	}
-	return s.r.reap(path, reaperParams)
-}
\ No newline at end of file
+	return s.r.reap(ctx, path, reaperParams)
+}
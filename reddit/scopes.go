@@ -0,0 +1,227 @@
+package reddit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultScopes are the OAuth2 scopes requested for apps which do not
+// configure their own.
+var DefaultScopes = []string{
+	"identity",
+	"read",
+	"privatemessages",
+	"submit",
+	"history",
+}
+
+// allScopes is the scope which grants access to every endpoint.
+const allScopes = "*"
+
+// validScopes are the OAuth2 scopes Reddit offers; see
+// https://www.reddit.com/api/v1/scopes
+var validScopes = map[string]bool{
+	allScopes:          true,
+	"account":          true,
+	"creddits":         true,
+	"edit":             true,
+	"flair":            true,
+	"history":          true,
+	"identity":         true,
+	"livemanage":       true,
+	"modconfig":        true,
+	"modcontributors":  true,
+	"modflair":         true,
+	"modlog":           true,
+	"modmail":          true,
+	"modothers":        true,
+	"modposts":         true,
+	"modself":          true,
+	"modwiki":          true,
+	"mysubreddits":     true,
+	"privatemessages":  true,
+	"read":             true,
+	"report":           true,
+	"save":             true,
+	"structuredstyles": true,
+	"submit":           true,
+	"subscribe":        true,
+	"vote":             true,
+	"wikiedit":         true,
+	"wikiread":         true,
+}
+
+// endpointScopes are the scopes required by the endpoints this package uses
+// which are not covered by the prefixes in prefixScopes.
+var endpointScopes = map[string]string{
+	"/api/comment":      "submit",
+	"/api/compose":      "privatemessages",
+	"/api/del":          "edit",
+	"/api/editusertext": "edit",
+	"/api/submit":       "submit",
+	"/api/info":         "read",
+	"/api/morechildren": "read",
+	"/api/vote":         "vote",
+	"/api/v1/me":        "identity",
+
+	"/api/approve":                       "modposts",
+	"/api/distinguish":                   "modposts",
+	"/api/lock":                          "modposts",
+	"/api/marknsfw":                      "modposts",
+	"/api/remove":                        "modposts",
+	"/api/set_contest_mode":              "modposts",
+	"/api/set_subreddit_sticky":          "modposts",
+	"/api/set_suggested_sort":            "modposts",
+	"/api/spoiler":                       "modposts",
+	"/api/unlock":                        "modposts",
+	"/api/unmarknsfw":                    "modposts",
+	"/api/unspoiler":                     "modposts",
+	"/api/v1/modactions/removal_reasons": "modposts",
+}
+
+// prefixScopes are the scopes required by endpoints under a path prefix.
+var prefixScopes = []struct {
+	prefix, scope string
+}{
+	{"/api/mod/conversations", "modmail"},
+	{"/message/", "privatemessages"},
+	{"/u/", "history"},
+	{"/user/", "history"},
+}
+
+// relationshipScopes are the scopes required to create or end each type of
+// relationship with /api/friend and /api/unfriend.
+var relationshipScopes = map[string]string{
+	banned:          "modcontributors",
+	muted:           "modcontributors",
+	contributor:     "modcontributors",
+	moderatorInvite: "modothers",
+	moderatorType:   "modothers",
+}
+
+// suffixScopes are the scopes required by endpoints with a path suffix, such
+// as subreddit listings.
+var suffixScopes = []struct {
+	suffix, scope string
+}{
+	{"/about", "read"},
+	{"/about/banned", "read"},
+	{"/about/contributors", "read"},
+	{"/about/log", "modlog"},
+	{"/about/moderators", "read"},
+	{"/about/muted", "read"},
+	{"/api/flaircsv", "modflair"},
+	{"/api/link_flair_v2", "flair"},
+	{"/api/selectflair", "flair"},
+	{"/api/user_flair_v2", "flair"},
+}
+
+func validateScopes(scopes []string) error {
+	for _, s := range scopes {
+		if !validScopes[s] {
+			return fmt.Errorf("unknown OAuth2 scope %q", s)
+		}
+	}
+	return nil
+}
+
+// requiredScope returns the scope a request needs, or "" if it is not known.
+func requiredScope(req *http.Request) string {
+	if req.URL == nil {
+		return ""
+	}
+
+	path := strings.TrimSuffix(req.URL.Path, ".json")
+	if scope, ok := endpointScopes[path]; ok {
+		return scope
+	}
+
+	if strings.HasSuffix(path, "/api/friend") ||
+		strings.HasSuffix(path, "/api/unfriend") {
+		return relationshipScopes[formValue(req, "type")]
+	}
+
+	// Suffixes are more specific than prefixes, e.g. /user/{name}/about
+	// is readable without access to the user's history.
+	for _, s := range suffixScopes {
+		if strings.HasSuffix(path, s.suffix) {
+			return s.scope
+		}
+	}
+
+	for _, p := range prefixScopes {
+		if strings.HasPrefix(path, p.prefix) {
+			return p.scope
+		}
+	}
+
+	return ""
+}
+
+// formValue returns a value of the form in a request's body, without consuming
+// the body, or "" if the body cannot be read again.
+func formValue(req *http.Request, key string) string {
+	if req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	raw, err := ioutil.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	values, err := url.ParseQuery(string(raw))
+	if err != nil {
+		return ""
+	}
+	return values.Get(key)
+}
+
+// grantedScopes returns the scopes listed in the "scope" field of a token
+// response, or nil if the response did not list them.
+func grantedScopes(field interface{}) map[string]bool {
+	list, ok := field.(string)
+	if !ok || list == "" {
+		return nil
+	}
+
+	granted := map[string]bool{}
+	for _, s := range strings.FieldsFunc(list, func(r rune) bool {
+		return r == ' ' || r == ','
+	}) {
+		granted[s] = true
+	}
+	return granted
+}
+
+// checkScope returns an error if the granted scopes are known and do not
+// cover the request.
+func checkScope(granted map[string]bool, req *http.Request) error {
+	if granted == nil || granted[allScopes] {
+		return nil
+	}
+
+	scope := requiredScope(req)
+	if scope == "" || granted[scope] {
+		return nil
+	}
+
+	return &APIError{
+		StatusCode: http.StatusForbidden,
+		Code:       insufficientScope,
+		Message: fmt.Sprintf(
+			"%s requires the %q scope, which was not granted",
+			req.URL.Path,
+			scope,
+		),
+	}
+}
@@ -9,11 +9,13 @@ import (
 type Script interface {
 	Lurker
 	Scanner
+	RateLimited
 }
 
 type script struct {
 	Lurker
 	Scanner
+	RateLimited
 }
 
 type ScriptConfig struct {
@@ -24,6 +26,10 @@ type ScriptConfig struct {
 	Rate time.Duration
 	// Custom HTTP client
 	Client *http.Client
+	// Retry configures how requests which fail with transient errors are
+	// retried. If unset, DefaultRetryPolicy is used; set MaxAttempts to 1
+	// to disable retries.
+	Retry RetryPolicy
 }
 
 // NewScript returns a Script handle to Reddit's API which always sends the
@@ -50,10 +56,12 @@ func NewScriptFromConfig(config ScriptConfig) (Script, error) {
 			reapSuffix: ".json",
 			tls:        true,
 			rate:       maxOf(config.Rate, 2*time.Second),
+			retry:      config.Retry.orDefault(),
 		},
 	)
 	return &script{
-		Lurker:  newLurker(r),
-		Scanner: newScanner(r),
+		Lurker:      newLurker(r),
+		Scanner:     newScanner(r),
+		RateLimited: c,
 	}, err
 }
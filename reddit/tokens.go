@@ -0,0 +1,133 @@
+package reddit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/turnage/graw/internal/atomicfile"
+	"golang.org/x/oauth2"
+)
+
+// TokenStore stores the OAuth2 token of an app using the authorization code
+// flow, so its refresh token survives restarts. Implementations must be safe
+// for use by many goroutines.
+type TokenStore interface {
+	// Token returns the stored token, or nil if none has been stored.
+	Token() (*oauth2.Token, error)
+	// SetToken stores the token.
+	SetToken(token *oauth2.Token) error
+}
+
+type fileTokenStore struct {
+	filename string
+	mu       *sync.Mutex
+}
+
+// NewFileTokenStore returns a TokenStore which keeps the token in a JSON file,
+// created on the first save if it does not exist. The file holds credentials
+// to the account, so it is only readable by its owner.
+func NewFileTokenStore(filename string) TokenStore {
+	return &fileTokenStore{filename: filename, mu: &sync.Mutex{}}
+}
+
+func (f *fileTokenStore) Token() (*oauth2.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	buf, err := ioutil.ReadFile(f.filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	token := &oauth2.Token{}
+	return token, json.Unmarshal(buf, token)
+}
+
+func (f *fileTokenStore) SetToken(token *oauth2.Token) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	buf, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(f.filename, buf)
+}
+
+// storingTokenSource saves the tokens from a token source to a store whenever
+// they change.
+type storingTokenSource struct {
+	src   oauth2.TokenSource
+	store TokenStore
+	// last is the access token most recently saved.
+	last string
+	mu   *sync.Mutex
+}
+
+func (s *storingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if token.AccessToken == s.last {
+		return token, nil
+	}
+
+	if err := s.store.SetToken(token); err != nil {
+		return nil, err
+	}
+
+	s.last = token.AccessToken
+	return token, nil
+}
+
+// refreshingTokenSource is a token source which claims a new token with fetch
+// when it has none, or when its token is within early of expiring. Only one
+// token is claimed at a time, so it is safe for use by many goroutines.
+type refreshingTokenSource struct {
+	// fetch claims a new token, given the current token if there is one.
+	fetch func(current *oauth2.Token) (*oauth2.Token, error)
+	early time.Duration
+	token *oauth2.Token
+	mu    *sync.Mutex
+}
+
+func (r *refreshingTokenSource) Token() (*oauth2.Token, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fresh() {
+		return r.token, nil
+	}
+
+	token, err := r.fetch(r.token)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RefreshToken == "" && r.token != nil {
+		token.RefreshToken = r.token.RefreshToken
+	}
+
+	r.token = token
+	return token, nil
+}
+
+// fresh is true if the current token can be used without claiming another.
+func (r *refreshingTokenSource) fresh() bool {
+	if r.token == nil || r.token.AccessToken == "" {
+		return false
+	}
+
+	return r.token.Expiry.IsZero() || time.Until(r.token.Expiry) > r.early
+}
@@ -1,11 +1,11 @@
 package graw
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/turnage/graw/botfaces"
 	"github.com/turnage/graw/reddit"
-	"github.com/turnage/graw/streams"
 )
 
 var (
@@ -21,6 +21,15 @@ var (
 	messageHandlerErr = fmt.Errorf(
 		"You must implement MessageHandler to take message feeds.",
 	)
+	modQueueHandlerErr = fmt.Errorf(
+		"You must implement ModQueueHandler to take moderation feeds.",
+	)
+	modActionHandlerErr = fmt.Errorf(
+		"You must implement ModActionHandler to take moderation log feeds.",
+	)
+	modmailHandlerErr = fmt.Errorf(
+		"You must implement ModmailHandler to take modmail feeds.",
+	)
 )
 
 // Run connects a handler to any requested event sources and makes requests with
@@ -34,14 +43,17 @@ func Run(handler interface{}, bot reddit.Bot, cfg Config) (
 ) {
 	kill := make(chan bool)
 	errs := make(chan error)
+	ctx, cancel := killContext(kill)
 
 	if err := connectAllStreams(
+		ctx,
 		handler,
 		bot,
 		cfg,
 		kill,
 		errs,
 	); err != nil {
+		cancel()
 		return nil, nil, err
 	}
 
@@ -49,13 +61,17 @@ func Run(handler interface{}, bot reddit.Bot, cfg Config) (
 }
 
 func connectAllStreams(
+	ctx context.Context,
 	handler interface{},
 	bot reddit.Bot,
 	c Config,
 	kill <-chan bool,
 	errs chan<- error,
 ) error {
+	st := c.streamer(ctx)
+
 	if err := connectScanStreams(
+		ctx,
 		handler,
 		bot,
 		c,
@@ -70,7 +86,7 @@ func connectAllStreams(
 	if c.PostReplies {
 		if prh, ok := handler.(botfaces.PostReplyHandler); !ok {
 			return postReplyHandlerErr
-		} else if prs, err := streams.PostReplies(
+		} else if prs, err := st.PostReplies(
 			bot,
 			kill,
 			errs,
@@ -88,7 +104,7 @@ func connectAllStreams(
 	if c.CommentReplies {
 		if crh, ok := handler.(botfaces.CommentReplyHandler); !ok {
 			return commentReplyHandlerErr
-		} else if crs, err := streams.CommentReplies(
+		} else if crs, err := st.CommentReplies(
 			bot,
 			kill,
 			errs,
@@ -106,7 +122,7 @@ func connectAllStreams(
 	if c.Mentions {
 		if mh, ok := handler.(botfaces.MentionHandler); !ok {
 			return mentionHandlerErr
-		} else if ms, err := streams.Mentions(
+		} else if ms, err := st.Mentions(
 			bot,
 			kill,
 			errs,
@@ -124,7 +140,7 @@ func connectAllStreams(
 	if c.Messages {
 		if mh, ok := handler.(botfaces.MessageHandler); !ok {
 			return messageHandlerErr
-		} else if ms, err := streams.Messages(
+		} else if ms, err := st.Messages(
 			bot,
 			kill,
 			errs,
@@ -139,5 +155,139 @@ func connectAllStreams(
 		}
 	}
 
+	if len(c.ModLog) > 0 {
+		if mah, ok := handler.(botfaces.ModActionHandler); !ok {
+			return modActionHandlerErr
+		} else if actions, err := st.ModLog(
+			bot,
+			kill,
+			errs,
+			c.ModLog...,
+		); err != nil {
+			return err
+		} else {
+			go func() {
+				for a := range actions {
+					errs <- mah.ModAction(a)
+				}
+			}()
+		}
+	}
+
+	if len(c.FlairEdits) > 0 {
+		if pfh, ok := handler.(botfaces.PostFlairHandler); !ok {
+			return postFlairHandlerErr
+		} else if posts, err := st.FlairEdits(
+			bot,
+			kill,
+			errs,
+			c.FlairEdits...,
+		); err != nil {
+			return err
+		} else {
+			go func() {
+				for p := range posts {
+					errs <- pfh.PostFlair(p)
+				}
+			}()
+		}
+	}
+
+	if len(c.Modmail) > 0 {
+		if mh, ok := handler.(botfaces.ModmailHandler); !ok {
+			return modmailHandlerErr
+		} else if ms, err := st.Modmail(
+			bot,
+			kill,
+			errs,
+			c.Modmail...,
+		); err != nil {
+			return err
+		} else {
+			go func() {
+				for m := range ms {
+					errs <- mh.Modmail(m)
+				}
+			}()
+		}
+	}
+
+	if c.modQueues() {
+		mqh, ok := handler.(botfaces.ModQueueHandler)
+		if !ok {
+			return modQueueHandlerErr
+		}
+
+		return connectModStreams(ctx, mqh, bot, c, kill, errs)
+	}
+
+	return nil
+}
+
+// connectModStreams connects the moderation listings requested in the config
+// to the handler.
+func connectModStreams(
+	ctx context.Context,
+	mqh botfaces.ModQueueHandler,
+	bot reddit.Bot,
+	c Config,
+	kill <-chan bool,
+	errs chan<- error,
+) error {
+	st := c.streamer(ctx)
+
+	forward := func(
+		listing string,
+		posts <-chan *reddit.Post,
+		comments <-chan *reddit.Comment,
+	) {
+		go func() {
+			for p := range posts {
+				errs <- mqh.ModQueuePost(listing, p)
+			}
+		}()
+		if comments != nil {
+			go func() {
+				for c := range comments {
+					errs <- mqh.ModQueueComment(listing, c)
+				}
+			}()
+		}
+	}
+
+	// lol no generics:
+
+	if len(c.ModQueue) > 0 {
+		posts, comments, err := st.ModQueue(bot, kill, errs, c.ModQueue...)
+		if err != nil {
+			return err
+		}
+		forward("modqueue", posts, comments)
+	}
+
+	if len(c.Reports) > 0 {
+		posts, comments, err := st.Reports(bot, kill, errs, c.Reports...)
+		if err != nil {
+			return err
+		}
+		forward("reports", posts, comments)
+	}
+
+	if len(c.Spam) > 0 {
+		posts, comments, err := st.Spam(bot, kill, errs, c.Spam...)
+		if err != nil {
+			return err
+		}
+		forward("spam", posts, comments)
+	}
+
+	if len(c.Unmoderated) > 0 {
+		posts, err := st.Unmoderated(bot, kill, errs, c.Unmoderated...)
+		if err != nil {
+			return err
+		}
+		forward("unmoderated", posts, nil)
+	}
+
 	return nil
 }
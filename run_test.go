@@ -0,0 +1,107 @@
+package graw
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/turnage/graw/reddit"
+)
+
+// queueBot serves one post from every listing after the first request of it,
+// as if it was posted once the stream started.
+type queueBot struct {
+	reddit.Bot
+
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func (b *queueBot) ListingContext(
+	_ context.Context,
+	path, _ string,
+) (reddit.Harvest, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.requests[path]++
+	if b.requests[path] != 2 {
+		return reddit.Harvest{}, nil
+	}
+
+	return reddit.Harvest{
+		Posts: []*reddit.Post{{Name: "t3_" + path, Permalink: path}},
+	}, nil
+}
+
+type queueHandler struct {
+	posts chan string
+}
+
+func (h *queueHandler) ModQueuePost(listing string, p *reddit.Post) error {
+	h.posts <- listing + " " + p.Permalink
+	return nil
+}
+
+func (h *queueHandler) ModQueueComment(string, *reddit.Comment) error {
+	return nil
+}
+
+func TestRunModQueues(t *testing.T) {
+	h := &queueHandler{posts: make(chan string)}
+	stop, _, err := Run(h, &queueBot{requests: map[string]int{}}, Config{
+		ModQueue:    []string{"a"},
+		Reports:     []string{"b"},
+		Spam:        []string{"c"},
+		Unmoderated: []string{"d"},
+	})
+	if err != nil {
+		t.Fatalf("failed to run: %v", err)
+	}
+	defer stop()
+
+	got := map[string]bool{}
+	for len(got) < 4 {
+		select {
+		case p := <-h.posts:
+			got[p] = true
+		case <-time.After(time.Second):
+			t.Fatalf("got posts %v; wanted one from each listing", got)
+		}
+	}
+
+	for _, want := range []string{
+		"modqueue /r/a/about/modqueue",
+		"reports /r/b/about/reports",
+		"spam /r/c/about/spam",
+		"unmoderated /r/d/about/unmoderated",
+	} {
+		if !got[want] {
+			t.Errorf("wanted %q delivered; got %v", want, got)
+		}
+	}
+}
+
+func TestRunModQueuesNeedHandler(t *testing.T) {
+	_, _, err := Run(
+		struct{}{},
+		&queueBot{requests: map[string]int{}},
+		Config{Spam: []string{"a"}},
+	)
+	if err != modQueueHandlerErr {
+		t.Errorf("got %v; wanted %v", err, modQueueHandlerErr)
+	}
+}
+
+func TestScanRejectsModQueues(t *testing.T) {
+	for i, cfg := range []Config{
+		{ModQueue: []string{"a"}},
+		{Reports: []string{"a"}},
+		{Spam: []string{"a"}},
+		{Unmoderated: []string{"a"}},
+	} {
+		if _, _, err := Scan(&queueHandler{}, nil, cfg); err != loggedOutErr {
+			t.Errorf("%d: got %v; wanted %v", i, err, loggedOutErr)
+		}
+	}
+}
@@ -1,11 +1,11 @@
 package graw
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/turnage/graw/botfaces"
 	"github.com/turnage/graw/reddit"
-	"github.com/turnage/graw/streams"
 )
 
 var (
@@ -16,11 +16,15 @@ var (
 		"You must implement CommentHandler to handle subreddit " +
 			"comment feeds.",
 	)
+	postFlairHandlerErr = fmt.Errorf(
+		"You must implement PostFlairHandler to handle post flair feeds.",
+	)
 	userHandlerErr = fmt.Errorf(
 		"You must implement UserHandler to handle user feeds.",
 	)
 	loggedOutErr = fmt.Errorf(
-		"You must be running as a logged in bot to get inbox feeds.",
+		"You must be running as a logged in bot to get inbox or " +
+			"moderation feeds.",
 	)
 )
 
@@ -40,13 +44,21 @@ func Scan(handler interface{}, script reddit.Script, cfg Config) (
 		return nil, nil, loggedOutErr
 	}
 
+	if cfg.modQueues() || len(cfg.ModLog) > 0 || len(cfg.Modmail) > 0 ||
+		len(cfg.FlairEdits) > 0 {
+		return nil, nil, loggedOutErr
+	}
+
+	ctx, cancel := killContext(kill)
 	if err := connectScanStreams(
+		ctx,
 		handler,
 		script,
 		cfg,
 		kill,
 		errs,
 	); err != nil {
+		cancel()
 		return nil, nil, err
 	}
 
@@ -56,19 +68,22 @@ func Scan(handler interface{}, script reddit.Script, cfg Config) (
 // connectScanStreams connects the streams a scanner can subscribe to to the
 // handler.
 func connectScanStreams(
+	ctx context.Context,
 	handler interface{},
 	sc reddit.Scanner,
 	c Config,
 	kill <-chan bool,
 	errs chan<- error,
 ) error {
+	st := c.streamer(ctx)
+
 	if len(c.Subreddits) > 0 {
 		ph, ok := handler.(botfaces.PostHandler)
 		if !ok {
 			return postHandlerErr
 		}
 
-		if posts, err := streams.Subreddits(
+		if posts, err := st.Subreddits(
 			sc,
 			kill,
 			errs,
@@ -91,7 +106,7 @@ func connectScanStreams(
 		}
 
 		for user, feeds := range c.CustomFeeds {
-			if posts, err := streams.CustomFeeds(
+			if posts, err := st.CustomFeeds(
 				sc,
 				kill,
 				errs,
@@ -109,13 +124,35 @@ func connectScanStreams(
 		}
 	}
 
+	if len(c.PostFlair) > 0 {
+		pfh, ok := handler.(botfaces.PostFlairHandler)
+		if !ok {
+			return postFlairHandlerErr
+		}
+
+		if posts, err := st.PostFlair(
+			sc,
+			kill,
+			errs,
+			c.PostFlair...,
+		); err != nil {
+			return err
+		} else {
+			go func() {
+				for p := range posts {
+					errs <- pfh.PostFlair(p)
+				}
+			}()
+		}
+	}
+
 	if len(c.SubredditComments) > 0 {
 		ch, ok := handler.(botfaces.CommentHandler)
 		if !ok {
 			return commentHandlerErr
 		}
 
-		if comments, err := streams.SubredditComments(
+		if comments, err := st.SubredditComments(
 			sc,
 			kill,
 			errs,
@@ -138,7 +175,7 @@ func connectScanStreams(
 		}
 
 		for _, user := range c.Users {
-			if posts, comments, err := streams.User(
+			if posts, comments, err := st.User(
 				sc,
 				kill,
 				errs,
@@ -0,0 +1,81 @@
+// Package checkpoint persists the positions of streams in Reddit listings, so
+// that streams can resume where they left off when a bot is restarted.
+package checkpoint
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/turnage/graw/internal/atomicfile"
+)
+
+// Store saves and restores the positions of listing monitors. A position is a
+// list of Reddit thing names, youngest first, which the monitor uses as
+// reference points in the listing. Streams which are not backed by listings,
+// such as modmail, save their own kind of position. Implementations must be
+// safe for use by many goroutines, since every stream in a graw run shares one
+// Store.
+type Store interface {
+	// Load returns the position saved for the listing at path, or nil if
+	// no position has been saved for it.
+	Load(path string) ([]string, error)
+	// Save saves the position of the monitor of the listing at path.
+	Save(path string, tip []string) error
+}
+
+type fileStore struct {
+	filename string
+	tips     map[string][]string
+	mu       *sync.Mutex
+}
+
+// NewFile returns a Store which keeps positions in a JSON file. If the file
+// does not exist it is created on the first save. Each save rewrites the whole
+// file, replacing it atomically.
+func NewFile(filename string) (Store, error) {
+	f := &fileStore{
+		filename: filename,
+		tips:     make(map[string][]string),
+		mu:       &sync.Mutex{},
+	}
+
+	buf, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return f, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(buf) == 0 {
+		return f, nil
+	}
+
+	return f, json.Unmarshal(buf, &f.tips)
+}
+
+func (f *fileStore) Load(path string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tip := f.tips[path]
+	if tip == nil {
+		return nil, nil
+	}
+
+	return append([]string(nil), tip...), nil
+}
+
+func (f *fileStore) Save(path string, tip []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.tips[path] = append([]string(nil), tip...)
+	buf, err := json.MarshalIndent(f.tips, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(f.filename, buf)
+}
@@ -0,0 +1,50 @@
+package checkpoint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatalf("failed to make test directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "tips.json")
+
+	s, err := NewFile(filename)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	if tip, err := s.Load("/r/self/new"); err != nil || tip != nil {
+		t.Errorf("wanted no tip from empty store; got %v, %v", tip, err)
+	}
+
+	expected := []string{"t3_b", "t3_a"}
+	if err := s.Save("/r/self/new", expected); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	s, err = NewFile(filename)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+
+	tip, err := s.Load("/r/self/new")
+	if err != nil {
+		t.Errorf("failed to load: %v", err)
+	}
+
+	if !reflect.DeepEqual(tip, expected) {
+		t.Errorf("got %v; wanted %v", tip, expected)
+	}
+
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("wanted only the store file left behind; got %d", len(files))
+	}
+}
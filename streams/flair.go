@@ -0,0 +1,218 @@
+package streams
+
+import (
+	"strings"
+	"time"
+
+	"github.com/turnage/graw/reddit"
+)
+
+// flairEditAction is the moderation log action recorded when a moderator
+// changes the flair of a post or user.
+const flairEditAction = "editflair"
+
+// flairRetryDelay is how long a flair edit stream waits before looking up a
+// post again after failing to.
+var flairRetryDelay = 5 * time.Second
+
+// flairWatcher finds posts whose flair changed among the newest posts of a
+// listing.
+type flairWatcher struct {
+	lister func(path string, params map[string]string) (reddit.Harvest, error)
+	path   string
+	// flairs are the last seen flairs of the posts in the listing, by name.
+	flairs map[string]postFlair
+}
+
+// postFlair is the part of a post which changes when its flair does.
+type postFlair struct {
+	text, cssClass, templateID string
+}
+
+// PostFlair returns a stream of posts whose flair changed, from among the 100
+// newest posts in the requested subreddits. Each post is delivered with its new
+// flair, whether its author or a moderator changed it. Posts are checked for
+// changes from the first time they are seen, so changes made before the stream
+// started, or while it was not running, are not delivered; see FlairEdits for
+// a stream of moderators' changes which does not miss them. This will consume
+// one interval of the handle per update.
+func PostFlair(
+	scanner reddit.Scanner,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	error,
+) {
+	return (&Streamer{}).PostFlair(scanner, kill, errs, subreddits...)
+}
+
+// PostFlair is like the package level PostFlair. Flair changes have no position
+// in a listing to save, so the Streamer's checkpoints are not used.
+func (s *Streamer) PostFlair(
+	scanner reddit.Scanner,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	error,
+) {
+	ctx := s.context(kill)
+	w, err := newFlairWatcher(
+		func(path string, params map[string]string) (reddit.Harvest, error) {
+			return scanner.ListingWithParamsContext(ctx, path, params)
+		},
+		"/r/"+strings.Join(subreddits, "+")+"/new",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make(chan *reddit.Post)
+	go func() {
+		for {
+			select {
+			case <-kill:
+				close(posts)
+				return
+			default:
+				changed, err := w.update()
+				for _, p := range changed {
+					posts <- p
+				}
+				report(kill, errs, err)
+			}
+		}
+	}()
+
+	return posts, nil
+}
+
+func newFlairWatcher(
+	lister func(string, map[string]string) (reddit.Harvest, error),
+	path string,
+) (*flairWatcher, error) {
+	w := &flairWatcher{lister: lister, path: path}
+	if _, err := w.update(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// update returns the posts whose flair changed since the last update. Only the
+// posts in the listing now are remembered, so the watcher's memory does not grow.
+func (w *flairWatcher) update() ([]*reddit.Post, error) {
+	h, err := w.lister(w.path, map[string]string{"limit": "100"})
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []*reddit.Post
+	flairs := make(map[string]postFlair, len(h.Posts))
+	for _, p := range h.Posts {
+		flair := postFlair{
+			text:       p.LinkFlairText,
+			cssClass:   p.LinkFlairCSSClass,
+			templateID: p.LinkFlairTemplateID,
+		}
+		if last, ok := w.flairs[p.Name]; ok && last != flair {
+			changed = append(changed, p)
+		}
+		flairs[p.Name] = flair
+	}
+
+	w.flairs = flairs
+	return changed, nil
+}
+
+// FlairEdits returns a stream of posts whose flair was changed by moderators
+// of the requested subreddits, which the bot's account must moderate, as
+// recorded by "editflair" actions in their moderation logs. Each post is
+// fetched when its change is seen, so it is delivered with its current flair.
+// Flair that authors select for their own posts is not logged; use PostFlair
+// for those changes. The account must be granted the "modlog" scope. It
+// consumes one interval of the handle per update, plus one for each changed
+// post.
+func FlairEdits(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	error,
+) {
+	return (&Streamer{}).FlairEdits(bot, kill, errs, subreddits...)
+}
+
+// FlairEdits is like the package level FlairEdits, but provisions its stream
+// from the Streamer. A resuming stream delivers the posts of the flair changes
+// it missed, as ModLog does; its position is saved apart from any ModLog
+// stream of the same subreddits.
+func (s *Streamer) FlairEdits(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	error,
+) {
+	path := "/r/" + strings.Join(subreddits, "+") + "/about/log"
+	_, _, _, actions, err := s.streamFromKeyedPath(
+		bot,
+		kill,
+		errs,
+		path,
+		"flair:"+path,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := s.context(kill)
+	getInfo := func(fullnames ...string) (reddit.Harvest, error) {
+		return bot.GetInfoContext(ctx, fullnames...)
+	}
+	return flairEdits(actions, getInfo, kill, errs), nil
+}
+
+// flairEdits looks up the posts targeted by the flair edits among the actions
+// and streams them, until the actions are closed or the stream is killed. A
+// failed lookup is reported and retried, so no edit is skipped.
+func flairEdits(
+	actions <-chan *reddit.ModAction,
+	getInfo func(fullnames ...string) (reddit.Harvest, error),
+	kill <-chan bool,
+	errs chan<- error,
+) <-chan *reddit.Post {
+	posts := make(chan *reddit.Post)
+	go func() {
+		defer close(posts)
+		for a := range actions {
+			if a.Action != flairEditAction ||
+				!strings.HasPrefix(a.TargetFullname, "t3_") {
+				continue
+			}
+
+			h, err := getInfo(a.TargetFullname)
+			for err != nil {
+				report(kill, errs, err)
+				select {
+				case <-kill:
+					return
+				case <-time.After(flairRetryDelay):
+				}
+				h, err = getInfo(a.TargetFullname)
+			}
+
+			for _, p := range h.Posts {
+				posts <- p
+			}
+		}
+	}()
+
+	return posts
+}
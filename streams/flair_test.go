@@ -0,0 +1,157 @@
+package streams
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/turnage/graw/reddit"
+)
+
+func TestFlairWatcher(t *testing.T) {
+	posts := []*reddit.Post{
+		{Name: "t3_a", LinkFlairText: "Question"},
+		{Name: "t3_b"},
+	}
+	var path string
+	lister := func(p string, _ map[string]string) (reddit.Harvest, error) {
+		path = p
+		var copied []*reddit.Post
+		for _, post := range posts {
+			c := *post
+			copied = append(copied, &c)
+		}
+		return reddit.Harvest{Posts: copied}, nil
+	}
+
+	w, err := newFlairWatcher(lister, "/r/self+golang/new")
+	if err != nil {
+		t.Fatalf("failed to make watcher: %v", err)
+	}
+
+	if path != "/r/self+golang/new" {
+		t.Errorf("wrong path: %s", path)
+	}
+
+	if changed, err := w.update(); err != nil || len(changed) != 0 {
+		t.Errorf("wanted no changes; got %v, %v", changed, err)
+	}
+
+	posts[0].LinkFlairText = "Solved"
+	posts = append([]*reddit.Post{{Name: "t3_c", LinkFlairText: "New"}}, posts...)
+
+	changed, err := w.update()
+	if err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	if len(changed) != 1 || changed[0].Name != "t3_a" ||
+		changed[0].LinkFlairText != "Solved" {
+		t.Errorf("wanted t3_a with its new flair; got %v", changed)
+	}
+
+	posts[2].LinkFlairTemplateID = "t1"
+	if changed, err := w.update(); err != nil || len(changed) != 1 ||
+		changed[0].Name != "t3_b" {
+		t.Errorf("wanted t3_b for its new template; got %v, %v", changed, err)
+	}
+}
+
+func TestFlairEdits(t *testing.T) {
+	defer func(delay time.Duration) { flairRetryDelay = delay }(flairRetryDelay)
+	flairRetryDelay = 0
+
+	actions := make(chan *reddit.ModAction)
+	errs := make(chan error, 1)
+	var lookups []string
+	getInfo := func(fullnames ...string) (reddit.Harvest, error) {
+		lookups = append(lookups, fullnames...)
+		if len(lookups) == 1 {
+			return reddit.Harvest{}, fmt.Errorf("lookup failed")
+		}
+		return reddit.Harvest{
+			Posts: []*reddit.Post{
+				{Name: fullnames[0], LinkFlairText: "Solved"},
+			},
+		}, nil
+	}
+
+	posts := flairEdits(actions, getInfo, make(chan bool), errs)
+	go func() {
+		for _, a := range []*reddit.ModAction{
+			{Action: "removelink", TargetFullname: "t3_removed"},
+			{Action: "editflair", TargetFullname: "t2_user"},
+			{Action: "editflair", TargetFullname: "t3_a"},
+			{Action: "editflair", TargetFullname: "t3_b"},
+		} {
+			actions <- a
+		}
+		close(actions)
+	}()
+
+	var got []string
+	for p := range posts {
+		if p.LinkFlairText != "Solved" {
+			t.Errorf("wanted %s with its new flair; got %q", p.Name, p.LinkFlairText)
+		}
+		got = append(got, p.Name)
+	}
+
+	if len(got) != 2 || got[0] != "t3_a" || got[1] != "t3_b" {
+		t.Errorf("wanted t3_a and t3_b; got %v", got)
+	}
+
+	if len(lookups) != 3 || lookups[0] != "t3_a" || lookups[1] != "t3_a" {
+		t.Errorf("wanted the failed lookup of t3_a retried; got %v", lookups)
+	}
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Errorf("wanted the failed lookup reported")
+		}
+	default:
+		t.Errorf("wanted the failed lookup reported")
+	}
+}
+
+// keyStore records the keys positions are saved under.
+type keyStore struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+func (k *keyStore) Load(string) ([]string, error) {
+	return nil, nil
+}
+
+func (k *keyStore) Save(key string, _ []string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[key] = true
+	return nil
+}
+
+func TestFlairEditsCheckpoint(t *testing.T) {
+	store := &keyStore{keys: map[string]bool{}}
+	s := &Streamer{Checkpoints: store}
+	kill := make(chan bool)
+	defer close(kill)
+
+	if _, err := s.ModLog(&pathBot{}, kill, make(chan error), "sub"); err != nil {
+		t.Fatalf("failed to open mod log: %v", err)
+	}
+
+	if _, err := s.FlairEdits(&pathBot{}, kill, make(chan error), "sub"); err != nil {
+		t.Fatalf("failed to open flair edits: %v", err)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, key := range []string{"/r/sub/about/log", "flair:/r/sub/about/log"} {
+		if !store.keys[key] {
+			t.Errorf("wanted a position saved under %s; got %v", key, store.keys)
+		}
+	}
+}
@@ -2,8 +2,11 @@
 package monitor
 
 import (
+	"context"
+
 	"github.com/turnage/graw/reddit"
 
+	"github.com/turnage/graw/streams/checkpoint"
 	"github.com/turnage/graw/streams/internal/rsort"
 )
 
@@ -16,6 +19,17 @@ const (
 	// maxTipSize is the maximum size of the tip log (number of backup tips
 	// + the current tip).
 	maxTipSize = 20
+	// defaultCatchUp is the amount of items missed while a monitor was
+	// down it will deliver when resuming from a checkpoint, if its
+	// configuration does not say otherwise.
+	defaultCatchUp = 100
+	// pageSize is the amount of elements Reddit returns in a full page of
+	// a listing.
+	pageSize = 100
+	// maxBackfillPages is the maximum amount of pages the monitor will
+	// read in one update to fill a gap between its tip and the newest
+	// elements of the listing.
+	maxBackfillPages = 10
 )
 
 // defaultTip is the blank reference point in a Reddit listing, which asks for
@@ -34,11 +48,29 @@ type Config struct {
 	// Path is the path to the listing the monitor watches.
 	Path string
 
+	// Key is the name the monitor's position is saved under in
+	// Checkpoints. If empty, Path is used.
+	Key string
+
 	// Scanner is the api the monitor uses to read Reddit
 	Scanner reddit.Scanner
 
+	// Context, if set, is the context of the monitor's requests, which
+	// are cancelled once it is done. If nil, context.Background is used.
+	Context context.Context
+
 	// Sorter sorts the monitor's new listing elements.
 	Sorter rsort.Sorter
+
+	// Checkpoints, if set, is where the monitor saves its position in the
+	// listing after every update, and where it resumes from when created.
+	Checkpoints checkpoint.Store
+
+	// CatchUp is the maximum amount of items posted while the monitor was
+	// down that it delivers when resuming from a checkpoint. Older missed
+	// items are skipped. If CatchUp is not positive, defaultCatchUp is
+	// used.
+	CatchUp int
 }
 
 type monitor struct {
@@ -52,47 +84,162 @@ type monitor struct {
 	// path is the listing endpoint the monitor monitors. This path is
 	// appended to the reddit monitor url (e.g./user/robert).
 	path string
+	// key is the name the monitor's position is saved under.
+	key string
 
-	scanner reddit.Scanner
-	sorter  rsort.Sorter
+	// catchUp is the amount of items the next update may deliver when the
+	// monitor is catching up after resuming from a checkpoint, or 0 if it
+	// is not catching up.
+	catchUp int
+
+	scanner     reddit.Scanner
+	ctx         context.Context
+	sorter      rsort.Sorter
+	checkpoints checkpoint.Store
 }
 
 // New provides a monitor for the listing endpoint.
 func New(c Config) (Monitor, error) {
 	m := &monitor{
-		tip:            []string{""},
-		path:           c.Path,
-		scanner:        c.Scanner,
-		sorter:         c.Sorter,
+		tip:         []string{""},
+		path:        c.Path,
+		key:         c.Key,
+		scanner:     c.Scanner,
+		ctx:         c.Context,
+		sorter:      c.Sorter,
+		checkpoints: c.Checkpoints,
+	}
+	if m.ctx == nil {
+		m.ctx = context.Background()
+	}
+	if m.key == "" {
+		m.key = m.path
+	}
+
+	if resumed, err := m.resume(c.CatchUp); err != nil {
+		return nil, err
+	} else if resumed {
+		return m, nil
 	}
 
 	if err := m.sync(); err != nil {
 		return nil, err
 	}
 
+	if err := m.save(); err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
 
 // Update checks for new content at the monitored listing endpoint and forwards
-// new content to the bot for processing.
+// new content to the bot for processing. New content is ordered oldest first.
 func (m *monitor) Update() (reddit.Harvest, error) {
 	if m.blanks > blankThreshold {
-		return reddit.Harvest{}, m.fixTip()
+		if err := m.fixTip(); err != nil {
+			return reddit.Harvest{}, err
+		}
+		return reddit.Harvest{}, m.save()
 	}
 
 	names, harvest, err := m.harvest(m.tip[0])
+	if err == nil && len(names) >= pageSize && m.tip[0] != "" {
+		names, harvest, err = m.backfill(names, harvest)
+	}
+
+	// A failed fetch says nothing about the tip, so it is not counted as a
+	// blank poll, and the next update starts over from the same tip.
+	if err != nil {
+		return reddit.Harvest{}, err
+	}
+
+	if m.catchUp > 0 {
+		if len(names) > m.catchUp {
+			names = names[:m.catchUp]
+		}
+		m.catchUp = 0
+	}
+
+	harvest = chronological(harvest, names)
 	m.updateTip(names)
-	return harvest, err
+	return harvest, m.save()
 }
 
 // harvest fetches from the listing any posts after the given reference post,
 // and returns those posts and a reverse chronologically sorted list of their
 // names.
 func (m *monitor) harvest(ref string) ([]string, reddit.Harvest, error) {
-	h, err := m.scanner.Listing(m.path, ref)
+	h, err := m.scanner.ListingContext(m.ctx, m.path, ref)
 	return m.sorter.Sort(h), h, err
 }
 
+// backfill is used when an update returns a full page of new elements, which
+// means more may have been posted since the last update than fit in a page. It
+// reads pages further back in the listing until it reaches an element the
+// monitor has seen, and returns the names of all the new elements (younger
+// names first) and a harvest containing them. If a page fails, nothing is
+// returned so the next update starts over from the same tip.
+func (m *monitor) backfill(
+	names []string,
+	h reddit.Harvest,
+) ([]string, reddit.Harvest, error) {
+	known := make(map[string]bool)
+	for _, n := range m.tip {
+		known[n] = true
+	}
+
+	page := names
+	names = unseen(page, known)
+	for i := 1; i < maxBackfillPages; i++ {
+		if len(names) < len(page) || len(page) < pageSize {
+			break
+		}
+
+		// A resuming monitor only delivers so many of the elements it
+		// missed, so there is no use reading further back.
+		if m.catchUp > 0 && len(names) >= m.catchUp {
+			break
+		}
+
+		next, err := m.scanner.ListingWithParamsContext(
+			m.ctx,
+			m.path,
+			map[string]string{"after": page[len(page)-1]},
+		)
+		if err != nil {
+			return nil, reddit.Harvest{}, err
+		}
+
+		page = m.sorter.Sort(next)
+		fresh := unseen(page, known)
+		names = append(names, fresh...)
+
+		// lol no generics
+		h.Posts = append(h.Posts, next.Posts...)
+		h.Comments = append(h.Comments, next.Comments...)
+		h.Messages = append(h.Messages, next.Messages...)
+		h.ModActions = append(h.ModActions, next.ModActions...)
+
+		if len(fresh) < len(page) {
+			break
+		}
+	}
+
+	return names, h, nil
+}
+
+// unseen returns the names in a list (younger names first) which are younger
+// than any of the known names in it.
+func unseen(names []string, known map[string]bool) []string {
+	for i, n := range names {
+		if known[n] {
+			return names[:i]
+		}
+	}
+	return names
+}
+
 // sync fetches the current tip of a listing endpoint, so that grawbots crawling
 // forward in time don't treat it as a new post, or reprocess it when restarted.
 func (m *monitor) sync() error {
@@ -105,6 +252,36 @@ func (m *monitor) sync() error {
 	return err
 }
 
+// resume restores the monitor's position from its checkpoint store, if it has
+// one and a position was saved. Returns whether the position was restored.
+func (m *monitor) resume(catchUp int) (bool, error) {
+	if m.checkpoints == nil {
+		return false, nil
+	}
+
+	tip, err := m.checkpoints.Load(m.key)
+	if err != nil || len(tip) == 0 {
+		return false, err
+	}
+
+	if catchUp <= 0 {
+		catchUp = defaultCatchUp
+	}
+
+	m.tip = tip
+	m.catchUp = catchUp
+	return true, nil
+}
+
+// save saves the monitor's position to its checkpoint store, if it has one.
+func (m *monitor) save() error {
+	if m.checkpoints == nil {
+		return nil
+	}
+
+	return m.checkpoints.Save(m.key, m.tip)
+}
+
 // updateTip updates the monitor's list of names from the endpoint listing it
 // uses to keep track of its position in the monitored listing.
 func (m *monitor) updateTip(names []string) {
@@ -156,3 +333,47 @@ func (m *monitor) fixTip() error {
 	m.blanks = 0
 	return nil
 }
+
+// chronological returns the elements of a harvest with the given names (in
+// order of younger names first), each kind ordered oldest first.
+func chronological(h reddit.Harvest, names []string) reddit.Harvest {
+	order := make(map[string]int)
+	for i, n := range names {
+		order[n] = len(names) - i
+	}
+
+	// lol no generics
+	posts := make([]*reddit.Post, len(names)+1)
+	comments := make([]*reddit.Comment, len(names)+1)
+	messages := make([]*reddit.Message, len(names)+1)
+	actions := make([]*reddit.ModAction, len(names)+1)
+	for _, p := range h.Posts {
+		posts[order[p.Name]] = p
+	}
+	for _, c := range h.Comments {
+		comments[order[c.Name]] = c
+	}
+	for _, msg := range h.Messages {
+		messages[order[msg.Name]] = msg
+	}
+	for _, a := range h.ModActions {
+		actions[order[a.Name]] = a
+	}
+
+	var result reddit.Harvest
+	for i := 1; i <= len(names); i++ {
+		if posts[i] != nil {
+			result.Posts = append(result.Posts, posts[i])
+		}
+		if comments[i] != nil {
+			result.Comments = append(result.Comments, comments[i])
+		}
+		if messages[i] != nil {
+			result.Messages = append(result.Messages, messages[i])
+		}
+		if actions[i] != nil {
+			result.ModActions = append(result.ModActions, actions[i])
+		}
+	}
+	return result
+}
@@ -1,10 +1,15 @@
 package monitor
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/turnage/graw/reddit"
+
+	"github.com/turnage/graw/streams/internal/rsort"
 )
 
 type mockScanner struct{}
@@ -18,6 +23,14 @@ func (m *mockScanner) ListingWithParams(_ string, _ map[string]string) (reddit.H
 	return reddit.Harvest{}, nil
 }
 
+func (m *mockScanner) ListingContext(_ context.Context, p, a string) (reddit.Harvest, error) {
+	return m.Listing(p, a)
+}
+
+func (m *mockScanner) ListingWithParamsContext(_ context.Context, p string, v map[string]string) (reddit.Harvest, error) {
+	return m.ListingWithParams(p, v)
+}
+
 type mockSorter struct {
 	names []string
 }
@@ -45,6 +58,28 @@ func TestNew(t *testing.T) {
 	}
 }
 
+// ctxScanner fails its listings once their context is done.
+type ctxScanner struct {
+	mockScanner
+}
+
+func (c *ctxScanner) ListingContext(ctx context.Context, _, _ string) (reddit.Harvest, error) {
+	return reddit.Harvest{}, ctx.Err()
+}
+
+func TestNewContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := New(Config{
+		Scanner: &ctxScanner{},
+		Sorter:  &mockSorter{},
+		Context: ctx,
+	}); err != context.Canceled {
+		t.Errorf("wanted the cancelled context to fail the monitor; got %v", err)
+	}
+}
+
 func TestShaveTip(t *testing.T) {
 	m := &monitor{
 		blanks:         5,
@@ -157,3 +192,269 @@ func TestTipStaysNonNil(t *testing.T) {
 		t.Errorf("error in second update: %v", err)
 	}
 }
+
+type mockStore struct {
+	tips map[string][]string
+}
+
+func (m *mockStore) Load(path string) ([]string, error) {
+	return m.tips[path], nil
+}
+
+func (m *mockStore) Save(path string, tip []string) error {
+	m.tips[path] = tip
+	return nil
+}
+
+func TestNewSavesTip(t *testing.T) {
+	store := &mockStore{tips: map[string][]string{}}
+	names := []string{"1", "2"}
+	if _, err := New(Config{
+		Path:        "path",
+		Scanner:     &mockScanner{},
+		Sorter:      &mockSorter{names},
+		Checkpoints: store,
+	}); err != nil {
+		t.Fatalf("error creating monitor: %v", err)
+	}
+
+	if !reflect.DeepEqual(store.tips["path"], names) {
+		t.Errorf("wanted tip saved; got %v", store.tips["path"])
+	}
+}
+
+func TestNewSavesTipUnderKey(t *testing.T) {
+	store := &mockStore{tips: map[string][]string{"path": {"0"}}}
+	names := []string{"1", "2"}
+	if _, err := New(Config{
+		Path:        "path",
+		Key:         "key",
+		Scanner:     &mockScanner{},
+		Sorter:      &mockSorter{names},
+		Checkpoints: store,
+	}); err != nil {
+		t.Fatalf("error creating monitor: %v", err)
+	}
+
+	if !reflect.DeepEqual(store.tips["key"], names) {
+		t.Errorf("wanted tip saved under key; got %v", store.tips["key"])
+	}
+
+	if !reflect.DeepEqual(store.tips["path"], []string{"0"}) {
+		t.Errorf("wanted tip under path untouched; got %v", store.tips["path"])
+	}
+}
+
+func TestResume(t *testing.T) {
+	store := &mockStore{tips: map[string][]string{"path": {"1", "2"}}}
+	m, err := New(Config{
+		Path:        "path",
+		Scanner:     &mockScanner{},
+		Sorter:      &mockSorter{[]string{"3", "4"}},
+		Checkpoints: store,
+	})
+	if err != nil {
+		t.Fatalf("error creating monitor: %v", err)
+	}
+
+	impl := m.(*monitor)
+	if expected := []string{"1", "2"}; !reflect.DeepEqual(impl.tip, expected) {
+		t.Errorf("wanted tip restored; got %v", impl.tip)
+	}
+
+	if impl.catchUp != defaultCatchUp {
+		t.Errorf("wanted default catch up; got %d", impl.catchUp)
+	}
+
+	if _, err := m.Update(); err != nil {
+		t.Errorf("error in update: %v", err)
+	}
+
+	expected := []string{"3", "4", "1", "2"}
+	if !reflect.DeepEqual(store.tips["path"], expected) {
+		t.Errorf("wanted tip saved after update; got %v", store.tips["path"])
+	}
+}
+
+type harvestScanner struct {
+	h reddit.Harvest
+}
+
+func (h *harvestScanner) Listing(_, _ string) (reddit.Harvest, error) {
+	return h.h, nil
+}
+
+func (h *harvestScanner) ListingWithParams(_ string, _ map[string]string) (reddit.Harvest, error) {
+	return h.h, nil
+}
+
+func (h *harvestScanner) ListingContext(_ context.Context, p, a string) (reddit.Harvest, error) {
+	return h.Listing(p, a)
+}
+
+func (h *harvestScanner) ListingWithParamsContext(_ context.Context, p string, v map[string]string) (reddit.Harvest, error) {
+	return h.ListingWithParams(p, v)
+}
+
+func TestCatchUpBound(t *testing.T) {
+	m := &monitor{
+		tip: []string{"1"},
+		scanner: &harvestScanner{reddit.Harvest{
+			Posts: []*reddit.Post{
+				&reddit.Post{Name: "4"},
+				&reddit.Post{Name: "3"},
+				&reddit.Post{Name: "2"},
+			},
+		}},
+		sorter:  &mockSorter{[]string{"4", "3", "2"}},
+		catchUp: 2,
+	}
+
+	h, err := m.Update()
+	if err != nil {
+		t.Errorf("error in update: %v", err)
+	}
+
+	if len(h.Posts) != 2 || h.Posts[0].Name != "3" || h.Posts[1].Name != "4" {
+		t.Errorf("wanted only the 2 youngest posts; got %v", h.Posts)
+	}
+
+	if expected := []string{"4", "3", "1"}; !reflect.DeepEqual(m.tip, expected) {
+		t.Errorf("wanted skipped items left out of tip; got %v", m.tip)
+	}
+
+	if m.catchUp != 0 {
+		t.Errorf("wanted catch up finished; got %d", m.catchUp)
+	}
+}
+
+// pagingScanner returns pages of posts named by the numbers in each page.
+type pagingScanner struct {
+	first  []int
+	pages  [][]int
+	afters []string
+}
+
+func postsNamed(numbers []int) reddit.Harvest {
+	var h reddit.Harvest
+	for _, n := range numbers {
+		h.Posts = append(h.Posts, &reddit.Post{
+			Name:       strconv.Itoa(n),
+			CreatedUTC: uint64(n),
+		})
+	}
+	return h
+}
+
+func (p *pagingScanner) Listing(_, _ string) (reddit.Harvest, error) {
+	return postsNamed(p.first), nil
+}
+
+func (p *pagingScanner) ListingWithParams(_ string, params map[string]string) (reddit.Harvest, error) {
+	p.afters = append(p.afters, params["after"])
+	if len(p.pages) == 0 {
+		return reddit.Harvest{}, fmt.Errorf("no more pages")
+	}
+	page := p.pages[0]
+	p.pages = p.pages[1:]
+	return postsNamed(page), nil
+}
+
+func (p *pagingScanner) ListingContext(_ context.Context, path, a string) (reddit.Harvest, error) {
+	return p.Listing(path, a)
+}
+
+func (p *pagingScanner) ListingWithParamsContext(_ context.Context, path string, v map[string]string) (reddit.Harvest, error) {
+	return p.ListingWithParams(path, v)
+}
+
+// span returns the numbers from high down to low.
+func span(high, low int) []int {
+	var numbers []int
+	for i := high; i >= low; i-- {
+		numbers = append(numbers, i)
+	}
+	return numbers
+}
+
+func TestBackfill(t *testing.T) {
+	sc := &pagingScanner{
+		first: span(250, 151),
+		pages: [][]int{span(150, 51), span(50, 1)},
+	}
+	m := &monitor{
+		tip:     []string{"10", "9"},
+		scanner: sc,
+		sorter:  rsort.New(),
+	}
+
+	h, err := m.Update()
+	if err != nil {
+		t.Fatalf("error in update: %v", err)
+	}
+
+	if expected := []string{"151", "51"}; !reflect.DeepEqual(sc.afters, expected) {
+		t.Errorf("wanted pages after %v; got %v", expected, sc.afters)
+	}
+
+	if len(h.Posts) != 240 {
+		t.Fatalf("wanted 240 new posts; got %d", len(h.Posts))
+	}
+
+	for i, p := range h.Posts {
+		if p.Name != strconv.Itoa(i+11) {
+			t.Fatalf("post %d out of order: %s", i, p.Name)
+		}
+	}
+
+	if m.tip[0] != "250" || len(m.tip) != maxTipSize {
+		t.Errorf("wanted tip moved to youngest post; got %v", m.tip)
+	}
+}
+
+func TestBackfillStopsAtTip(t *testing.T) {
+	sc := &pagingScanner{
+		first: span(200, 101),
+		pages: [][]int{span(100, 1)},
+	}
+	m := &monitor{
+		tip:     []string{"100"},
+		scanner: sc,
+		sorter:  rsort.New(),
+	}
+
+	h, err := m.Update()
+	if err != nil {
+		t.Fatalf("error in update: %v", err)
+	}
+
+	if len(sc.afters) != 1 {
+		t.Errorf("wanted 1 extra page read; got %v", sc.afters)
+	}
+
+	if len(h.Posts) != 100 {
+		t.Errorf("wanted 100 new posts; got %d", len(h.Posts))
+	}
+}
+
+func TestBackfillFailure(t *testing.T) {
+	m := &monitor{
+		tip:     []string{"100"},
+		scanner: &pagingScanner{first: span(200, 101)},
+		sorter:  rsort.New(),
+	}
+
+	for i := 0; i <= blankThreshold+1; i++ {
+		if _, err := m.Update(); err == nil {
+			t.Fatalf("%d: wanted the failed page reported", i)
+		}
+	}
+
+	if m.blanks != 0 {
+		t.Errorf("wanted failed backfills not counted as blanks; got %d", m.blanks)
+	}
+
+	if !reflect.DeepEqual(m.tip, []string{"100"}) {
+		t.Errorf("wanted tip kept for the next update; got %v", m.tip)
+	}
+}
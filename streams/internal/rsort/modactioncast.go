@@ -0,0 +1,23 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+package rsort
+
+import "github.com/turnage/graw/reddit"
+
+type modActionsThingImpl struct {
+	e *reddit.ModAction
+}
+
+func (g modActionsThingImpl) Name() string { return g.e.Name }
+
+func (g modActionsThingImpl) Birth() uint64 { return g.e.CreatedUTC }
+
+func modActionsAsThings(gs []*reddit.ModAction) []redditThing {
+	things := make([]redditThing, len(gs))
+	for i, g := range gs {
+		things[i] = &modActionsThingImpl{g}
+	}
+	return things
+}
@@ -1,6 +1,7 @@
 //go:generate genny -in=thingcast.tpl -out=postcast.go gen "ThingType=*reddit.Post name=posts NAME=Post"
 //go:generate genny -in=thingcast.tpl -out=commentcast.go gen "ThingType=*reddit.Comment name=comments NAME=Comment"
 //go:generate genny -in=thingcast.tpl -out=messagecast.go gen "ThingType=*reddit.Message name=messages NAME=Message"
+//go:generate genny -in=thingcast.tpl -out=modactioncast.go gen "ThingType=*reddit.ModAction name=modActions NAME=ModAction"
 // Package rsort provides tools for sorting Reddit elements.
 package rsort
 
@@ -42,6 +43,7 @@ func sortHarvest(h reddit.Harvest) []string {
 		postsAsThings(h.Posts),
 		commentsAsThings(h.Comments),
 		messagesAsThings(h.Messages),
+		modActionsAsThings(h.ModActions),
 	)
 	sort.Sort(byCreationTime{things})
 
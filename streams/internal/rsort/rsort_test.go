@@ -21,16 +21,19 @@ func TestSort(t *testing.T) {
 			Messages: []*reddit.Message{
 				&reddit.Message{CreatedUTC: 6, Name: "6"},
 			},
+			ModActions: []*reddit.ModAction{
+				&reddit.ModAction{CreatedUTC: 3, Name: "3"},
+			},
 		},
 	)
 
-	if len(names) != 6 {
-		t.Errorf("unexpected length; got %d; wanted %d", len(names), 6)
+	if len(names) != 7 {
+		t.Errorf("unexpected length; got %d; wanted %d", len(names), 7)
 	}
 
 	// Younger elements (those with later/higher creation times) should come
 	// first.
-	for i, name := range []string{"7", "6", "5", "2", "1", "0"} {
+	for i, name := range []string{"7", "6", "5", "3", "2", "1", "0"} {
 		if names[i] != name {
 			t.Errorf("%d wrong; got %s vs %v", i, names[i], name)
 		}
@@ -0,0 +1,224 @@
+package streams
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/turnage/graw/reddit"
+	"github.com/turnage/graw/streams/checkpoint"
+)
+
+// modmailPageSize is the number of most recently updated conversations checked
+// for new messages in every update.
+const modmailPageSize = 100
+
+// conversationReader is the part of reddit.Modmail a modmail stream uses.
+type conversationReader interface {
+	ConversationsContext(
+		ctx context.Context,
+		state string,
+		limit int,
+		subreddits ...string,
+	) ([]*reddit.Conversation, error)
+	ConversationContext(
+		ctx context.Context,
+		id string,
+		markRead bool,
+	) (*reddit.Conversation, error)
+}
+
+// Modmail returns a stream of new messages in the modmail conversations of the
+// requested subreddits. Use the subreddit "mod", or none, for all subreddits
+// the bot's account moderates. The account must be granted the "modmail"
+// scope, or reddit.InsufficientScopeErr is returned. Every update consumes one
+// interval of the handle, plus one for each conversation with new messages.
+func Modmail(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.ModmailMessage,
+	error,
+) {
+	return (&Streamer{}).Modmail(bot, kill, errs, subreddits...)
+}
+
+// Modmail is like the package level Modmail, but provisions its stream from
+// the Streamer. A resuming stream delivers the messages it missed in up to 100
+// of the most recently updated conversations.
+func (s *Streamer) Modmail(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.ModmailMessage,
+	error,
+) {
+	if len(subreddits) == 1 && subreddits[0] == "mod" {
+		subreddits = nil
+	}
+
+	modmail, ok := bot.Modmail()
+	if !ok {
+		return nil, reddit.InsufficientScopeErr
+	}
+
+	w, err := newModmailWatcher(
+		s.context(kill),
+		modmail,
+		s.Checkpoints,
+		subreddits,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(chan *reddit.ModmailMessage)
+	go func() {
+		for {
+			select {
+			case <-kill:
+				close(messages)
+				return
+			default:
+				msgs, err := w.update()
+				for _, m := range msgs {
+					messages <- m
+				}
+				report(kill, errs, err)
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+// modmailWatcher finds new messages in modmail conversations. Its position is
+// the update time of the most recently updated conversation it has seen.
+type modmailWatcher struct {
+	ctx        context.Context
+	mail       conversationReader
+	subreddits []string
+	since      time.Time
+
+	checkpoints checkpoint.Store
+	// key is the name the watcher's position is saved under.
+	key string
+}
+
+func newModmailWatcher(
+	ctx context.Context,
+	mail conversationReader,
+	checkpoints checkpoint.Store,
+	subreddits []string,
+) (*modmailWatcher, error) {
+	w := &modmailWatcher{
+		ctx:         ctx,
+		mail:        mail,
+		subreddits:  subreddits,
+		checkpoints: checkpoints,
+		key:         "modmail:" + strings.Join(subreddits, "+"),
+	}
+
+	if resumed, err := w.resume(); err != nil || resumed {
+		return w, err
+	}
+
+	convs, err := mail.ConversationsContext(
+		ctx,
+		"all",
+		modmailPageSize,
+		subreddits...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	w.since = latest(convs, w.since)
+	return w, w.save()
+}
+
+// update returns the messages posted since the last update, oldest first.
+func (w *modmailWatcher) update() ([]*reddit.ModmailMessage, error) {
+	convs, err := w.mail.ConversationsContext(
+		w.ctx,
+		"all",
+		modmailPageSize,
+		w.subreddits...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var fresh []*reddit.ModmailMessage
+	for _, c := range convs {
+		if !c.LastUpdated.After(w.since) {
+			continue
+		}
+
+		full, err := w.mail.ConversationContext(w.ctx, c.ID, false)
+		if err != nil {
+			return nil, err
+		}
+
+		// Messages posted after the listing was read are left for the
+		// next update, whose listing will include them; since only
+		// advances as far as the listing, taking them now would
+		// deliver them twice.
+		for _, m := range full.Messages {
+			if m.Date.After(w.since) && !m.Date.After(c.LastUpdated) {
+				fresh = append(fresh, m)
+			}
+		}
+	}
+
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].Date.Before(fresh[j].Date)
+	})
+
+	w.since = latest(convs, w.since)
+	return fresh, w.save()
+}
+
+// resume restores the watcher's position from its checkpoint store, if it has
+// one with a saved position.
+func (w *modmailWatcher) resume() (bool, error) {
+	if w.checkpoints == nil {
+		return false, nil
+	}
+
+	saved, err := w.checkpoints.Load(w.key)
+	if err != nil || len(saved) == 0 {
+		return false, err
+	}
+
+	w.since, err = time.Parse(time.RFC3339Nano, saved[0])
+	return err == nil, err
+}
+
+// save saves the watcher's position to its checkpoint store, if it has one.
+func (w *modmailWatcher) save() error {
+	if w.checkpoints == nil {
+		return nil
+	}
+
+	return w.checkpoints.Save(
+		w.key,
+		[]string{w.since.Format(time.RFC3339Nano)},
+	)
+}
+
+// latest returns the latest update time of the conversations, or since if
+// none were updated after it.
+func latest(convs []*reddit.Conversation, since time.Time) time.Time {
+	for _, c := range convs {
+		if c.LastUpdated.After(since) {
+			since = c.LastUpdated
+		}
+	}
+	return since
+}
@@ -0,0 +1,151 @@
+package streams
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turnage/graw/reddit"
+)
+
+// mockConversations serves a fixed set of conversations.
+type mockConversations struct {
+	convs map[string]*reddit.Conversation
+	// reads is the number of Conversation calls received.
+	reads int
+	// onRead, if set, is called with the id of every conversation read,
+	// before it is served.
+	onRead func(id string)
+}
+
+func (m *mockConversations) ConversationsContext(
+	_ context.Context,
+	_ string,
+	_ int,
+	_ ...string,
+) ([]*reddit.Conversation, error) {
+	var convs []*reddit.Conversation
+	for _, c := range m.convs {
+		listed := *c
+		convs = append(convs, &listed)
+	}
+	return convs, nil
+}
+
+func (m *mockConversations) ConversationContext(
+	_ context.Context,
+	id string,
+	_ bool,
+) (*reddit.Conversation, error) {
+	m.reads++
+	if m.onRead != nil {
+		m.onRead(id)
+	}
+	return m.convs[id], nil
+}
+
+func (m *mockConversations) post(id string, at time.Time) {
+	c, ok := m.convs[id]
+	if !ok {
+		c = &reddit.Conversation{ID: id}
+		m.convs[id] = c
+	}
+
+	c.LastUpdated = at
+	c.Messages = append(c.Messages, &reddit.ModmailMessage{
+		ID:             id + at.Format("150405"),
+		ConversationID: id,
+		Date:           at,
+	})
+}
+
+func TestModmailWatcher(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	mail := &mockConversations{convs: map[string]*reddit.Conversation{}}
+	mail.post("old", start)
+
+	w, err := newModmailWatcher(
+		context.Background(),
+		mail,
+		nil,
+		[]string{"sub"},
+	)
+	if err != nil {
+		t.Fatalf("failed to make watcher: %v", err)
+	}
+
+	if msgs, err := w.update(); err != nil || len(msgs) != 0 {
+		t.Errorf("wanted no messages before any are posted; got %v, %v", msgs, err)
+	}
+
+	mail.post("new", start.Add(2*time.Second))
+	mail.post("old", start.Add(time.Second))
+	mail.post("old", start.Add(3*time.Second))
+	mail.reads = 0
+
+	msgs, err := w.update()
+	if err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	if mail.reads != 2 {
+		t.Errorf("wanted 2 updated conversations read; got %d", mail.reads)
+	}
+
+	if len(msgs) != 3 {
+		t.Fatalf("wanted 3 new messages; got %d", len(msgs))
+	}
+
+	for i, offset := range []time.Duration{1, 2, 3} {
+		if want := start.Add(offset * time.Second); !msgs[i].Date.Equal(want) {
+			t.Errorf("%d: got message from %v; wanted %v", i, msgs[i].Date, want)
+		}
+	}
+
+	if msgs, err := w.update(); err != nil || len(msgs) != 0 {
+		t.Errorf("wanted no messages redelivered; got %v, %v", msgs, err)
+	}
+}
+
+func TestModmailWatcherReplyDuringUpdate(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	mail := &mockConversations{convs: map[string]*reddit.Conversation{}}
+	mail.post("conv", start)
+
+	w, err := newModmailWatcher(
+		context.Background(),
+		mail,
+		nil,
+		[]string{"sub"},
+	)
+	if err != nil {
+		t.Fatalf("failed to make watcher: %v", err)
+	}
+
+	// A reply arrives after the conversation is listed, but before it is
+	// read.
+	mail.post("conv", start.Add(time.Second))
+	mail.onRead = func(id string) {
+		mail.onRead = nil
+		mail.post(id, start.Add(2*time.Second))
+	}
+
+	var got []*reddit.ModmailMessage
+	for i := 0; i < 3; i++ {
+		msgs, err := w.update()
+		if err != nil {
+			t.Fatalf("%d: failed to update: %v", i, err)
+		}
+		got = append(got, msgs...)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("wanted each new message once; got %d messages", len(got))
+	}
+
+	for i, offset := range []time.Duration{1, 2} {
+		if want := start.Add(offset * time.Second); !got[i].Date.Equal(want) {
+			t.Errorf("%d: got message from %v; wanted %v", i, got[i].Date, want)
+		}
+	}
+}
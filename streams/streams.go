@@ -16,10 +16,9 @@
 // by close()ing the channel. Sending data over it will kill an arbitrary one of
 // the streams sharing the channel but not all of them.
 //
-// The error channel will return issues which may be intermittent. They are not
-// wrapped, so you can check them against the definitions in the reddit package
-// and choose to wait when Reddit is busy or the connection faults, instead of
-// failing.
+// The error channel will return issues which may be intermittent. You can check
+// them against the definitions in the reddit package with errors.Is and choose
+// to wait when Reddit is busy or the connection faults, instead of failing.
 //
 // If there is a problem setting up the stream, such as the endpoint being
 // invalid, that will be caught in the initial construction of the stream; you
@@ -32,17 +31,59 @@
 // E.g. if you create two user streams which depend on a handle with a rate
 // limit of 5 seconds, each of them will be unblocked once every 10 seconds
 // (ish), since they each consume one interval, and the interval is 5 seconds.
+//
+// By default streams start at the tip of their listing and only deliver
+// events that happen while they run. To resume streams where they left off
+// across restarts, provision them from a Streamer with a checkpoint store.
 package streams
 
 import (
+	"context"
 	"strings"
 
 	"github.com/turnage/graw/reddit"
 
+	"github.com/turnage/graw/streams/checkpoint"
 	"github.com/turnage/graw/streams/internal/monitor"
 	"github.com/turnage/graw/streams/internal/rsort"
 )
 
+// Streamer provisions streams which share a configuration. The package level
+// functions provision streams from a zero Streamer.
+type Streamer struct {
+	// Checkpoints, if set, is where streams save their position in their
+	// listing after every update. A stream created for a listing with a
+	// saved position resumes from it, delivering the events it missed.
+	Checkpoints checkpoint.Store
+	// CatchUp is the maximum number of missed events a resuming stream
+	// delivers; older missed events are skipped. If not positive, a
+	// default of 100 is used.
+	CatchUp int
+	// Context, if set, is the context of the requests streams make, which
+	// are cancelled once it is done. Requests are also cancelled when
+	// their stream is killed.
+	Context context.Context
+}
+
+// context returns the context of a stream's requests, which is cancelled when
+// the stream is killed.
+func (s *Streamer) context(kill <-chan bool) context.Context {
+	parent := s.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-kill:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+	return ctx
+}
+
 // Subreddits returns a stream of new posts from the requested subreddits. This
 // stream monitors the combination listing of all subreddits using Reddit's "+"
 // feature e.g. /r/golang+rust. This will consume one interval of the handle per
@@ -59,9 +100,23 @@ func Subreddits(
 ) (
 	<-chan *reddit.Post,
 	error,
+) {
+	return (&Streamer{}).Subreddits(scanner, kill, errs, subreddits...)
+}
+
+// Subreddits is like the package level Subreddits, but provisions its stream
+// from the Streamer.
+func (s *Streamer) Subreddits(
+	scanner reddit.Scanner,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	error,
 ) {
 	path := "/r/" + strings.Join(subreddits, "+") + "/new"
-	posts, _, _, err := streamFromPath(scanner, kill, errs, path)
+	posts, _, _, _, err := s.streamFromPath(scanner, kill, errs, path)
 	return posts, err
 }
 
@@ -78,9 +133,24 @@ func CustomFeeds(
 ) (
 	<-chan *reddit.Post,
 	error,
+) {
+	return (&Streamer{}).CustomFeeds(scanner, kill, errs, user, feeds...)
+}
+
+// CustomFeeds is like the package level CustomFeeds, but provisions its stream
+// from the Streamer.
+func (s *Streamer) CustomFeeds(
+	scanner reddit.Scanner,
+	kill <-chan bool,
+	errs chan<- error,
+	user string,
+	feeds ...string,
+) (
+	<-chan *reddit.Post,
+	error,
 ) {
 	path := "/user/" + user + "/m/" + strings.Join(feeds, "+") + "/new"
-	posts, _, _, err := streamFromPath(scanner, kill, errs, path)
+	posts, _, _, _, err := s.streamFromPath(scanner, kill, errs, path)
 	return posts, err
 }
 
@@ -101,9 +171,23 @@ func SubredditComments(
 ) (
 	<-chan *reddit.Comment,
 	error,
+) {
+	return (&Streamer{}).SubredditComments(scanner, kill, errs, subreddits...)
+}
+
+// SubredditComments is like the package level SubredditComments, but provisions
+// its stream from the Streamer.
+func (s *Streamer) SubredditComments(
+	scanner reddit.Scanner,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Comment,
+	error,
 ) {
 	path := "/r/" + strings.Join(subreddits, "+") + "/comments"
-	_, comments, _, err := streamFromPath(scanner, kill, errs, path)
+	_, comments, _, _, err := s.streamFromPath(scanner, kill, errs, path)
 	return comments, err
 }
 
@@ -118,9 +202,24 @@ func User(
 	<-chan *reddit.Post,
 	<-chan *reddit.Comment,
 	error,
+) {
+	return (&Streamer{}).User(scanner, kill, errs, user)
+}
+
+// User is like the package level User, but provisions its stream from the
+// Streamer.
+func (s *Streamer) User(
+	scanner reddit.Scanner,
+	kill <-chan bool,
+	errs chan<- error,
+	user string,
+) (
+	<-chan *reddit.Post,
+	<-chan *reddit.Comment,
+	error,
 ) {
 	path := "/u/" + user
-	posts, comments, _, err := streamFromPath(scanner, kill, errs, path)
+	posts, comments, _, _, err := s.streamFromPath(scanner, kill, errs, path)
 	return posts, comments, err
 }
 
@@ -134,7 +233,20 @@ func PostReplies(
 	<-chan *reddit.Message,
 	error,
 ) {
-	return inboxStream(bot, kill, errs, "selfreply")
+	return (&Streamer{}).PostReplies(bot, kill, errs)
+}
+
+// PostReplies is like the package level PostReplies, but provisions its stream
+// from the Streamer.
+func (s *Streamer) PostReplies(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+) (
+	<-chan *reddit.Message,
+	error,
+) {
+	return s.inboxStream(bot, kill, errs, "selfreply")
 }
 
 // CommentReplies returns a stream of replies to comments made by the bot's
@@ -147,7 +259,20 @@ func CommentReplies(
 	<-chan *reddit.Message,
 	error,
 ) {
-	return inboxStream(bot, kill, errs, "comments")
+	return (&Streamer{}).CommentReplies(bot, kill, errs)
+}
+
+// CommentReplies is like the package level CommentReplies, but provisions its
+// stream from the Streamer.
+func (s *Streamer) CommentReplies(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+) (
+	<-chan *reddit.Message,
+	error,
+) {
+	return s.inboxStream(bot, kill, errs, "comments")
 }
 
 // Mentions returns a stream of mentions of the bot's username anywhere on
@@ -162,7 +287,20 @@ func Mentions(
 	<-chan *reddit.Message,
 	error,
 ) {
-	return inboxStream(bot, kill, errs, "mentions")
+	return (&Streamer{}).Mentions(bot, kill, errs)
+}
+
+// Mentions is like the package level Mentions, but provisions its stream from
+// the Streamer.
+func (s *Streamer) Mentions(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+) (
+	<-chan *reddit.Message,
+	error,
+) {
+	return s.inboxStream(bot, kill, errs, "mentions")
 }
 
 // Messages returns a stream of messages sent to the bot's inbox. It consumes
@@ -174,10 +312,23 @@ func Messages(
 ) (
 	<-chan *reddit.Message,
 	error,
+) {
+	return (&Streamer{}).Messages(bot, kill, errs)
+}
+
+// Messages is like the package level Messages, but provisions its stream from
+// the Streamer.
+func (s *Streamer) Messages(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+) (
+	<-chan *reddit.Message,
+	error,
 ) {
 	onlyMessages := make(chan *reddit.Message)
 
-	messages, err := inboxStream(bot, kill, errs, "inbox")
+	messages, err := s.inboxStream(bot, kill, errs, "inbox")
 	go func() {
 		for m := range messages {
 			if !m.WasComment {
@@ -189,7 +340,184 @@ func Messages(
 	return onlyMessages, err
 }
 
-func inboxStream(
+// ModQueue returns a stream of posts and comments entering the moderation
+// queue of the requested subreddits, which the bot's account must moderate.
+// Use the subreddit "mod" for all subreddits the account moderates. It consumes
+// one interval of the handle.
+func ModQueue(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	<-chan *reddit.Comment,
+	error,
+) {
+	return (&Streamer{}).ModQueue(bot, kill, errs, subreddits...)
+}
+
+// ModQueue is like the package level ModQueue, but provisions its stream from
+// the Streamer.
+func (s *Streamer) ModQueue(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	<-chan *reddit.Comment,
+	error,
+) {
+	return s.modStream(bot, kill, errs, "modqueue", subreddits)
+}
+
+// Reports returns a stream of reported posts and comments in the requested
+// subreddits, which the bot's account must moderate. Use the subreddit "mod"
+// for all subreddits the account moderates. It consumes one interval of the
+// handle.
+func Reports(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	<-chan *reddit.Comment,
+	error,
+) {
+	return (&Streamer{}).Reports(bot, kill, errs, subreddits...)
+}
+
+// Reports is like the package level Reports, but provisions its stream from
+// the Streamer.
+func (s *Streamer) Reports(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	<-chan *reddit.Comment,
+	error,
+) {
+	return s.modStream(bot, kill, errs, "reports", subreddits)
+}
+
+// Spam returns a stream of posts and comments removed as spam, by moderators
+// or the spam filter, in the requested subreddits, which the bot's account
+// must moderate. Use the subreddit "mod" for all subreddits the account
+// moderates. It consumes one interval of the handle.
+func Spam(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	<-chan *reddit.Comment,
+	error,
+) {
+	return (&Streamer{}).Spam(bot, kill, errs, subreddits...)
+}
+
+// Spam is like the package level Spam, but provisions its stream from the
+// Streamer.
+func (s *Streamer) Spam(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	<-chan *reddit.Comment,
+	error,
+) {
+	return s.modStream(bot, kill, errs, "spam", subreddits)
+}
+
+// Unmoderated returns a stream of posts no moderator has approved or removed
+// yet in the requested subreddits, which the bot's account must moderate. Use
+// the subreddit "mod" for all subreddits the account moderates. It consumes
+// one interval of the handle.
+func Unmoderated(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	error,
+) {
+	return (&Streamer{}).Unmoderated(bot, kill, errs, subreddits...)
+}
+
+// Unmoderated is like the package level Unmoderated, but provisions its stream
+// from the Streamer.
+func (s *Streamer) Unmoderated(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.Post,
+	error,
+) {
+	posts, _, err := s.modStream(bot, kill, errs, "unmoderated", subreddits)
+	return posts, err
+}
+
+// ModLog returns a stream of actions moderators take in the requested
+// subreddits, which the bot's account must moderate, as they appear in the
+// moderation log. Use the subreddit "mod" for all subreddits the account
+// moderates. It consumes one interval of the handle.
+func ModLog(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.ModAction,
+	error,
+) {
+	return (&Streamer{}).ModLog(bot, kill, errs, subreddits...)
+}
+
+// ModLog is like the package level ModLog, but provisions its stream from the
+// Streamer.
+func (s *Streamer) ModLog(
+	bot reddit.Bot,
+	kill <-chan bool,
+	errs chan<- error,
+	subreddits ...string,
+) (
+	<-chan *reddit.ModAction,
+	error,
+) {
+	path := "/r/" + strings.Join(subreddits, "+") + "/about/log"
+	_, _, _, actions, err := s.streamFromPath(bot, kill, errs, path)
+	return actions, err
+}
+
+// modStream streams one of the moderation listings of the subreddits, e.g.
+// /r/golang+rust/about/modqueue.
+func (s *Streamer) modStream(
+	scanner reddit.Scanner,
+	kill <-chan bool,
+	errs chan<- error,
+	listing string,
+	subreddits []string,
+) (
+	<-chan *reddit.Post,
+	<-chan *reddit.Comment,
+	error,
+) {
+	path := "/r/" + strings.Join(subreddits, "+") + "/about/" + listing
+	posts, comments, _, _, err := s.streamFromPath(scanner, kill, errs, path)
+	return posts, comments, err
+}
+
+func (s *Streamer) inboxStream(
 	scanner reddit.Scanner,
 	kill <-chan bool,
 	errs chan<- error,
@@ -199,36 +527,64 @@ func inboxStream(
 	error,
 ) {
 	path := "/message/" + subpath
-	_, _, messages, err := streamFromPath(scanner, kill, errs, path)
+	_, _, messages, _, err := s.streamFromPath(scanner, kill, errs, path)
 	return messages, err
 }
 
-func streamFromPath(
+func (s *Streamer) streamFromPath(
+	scanner reddit.Scanner,
+	kill <-chan bool,
+	errs chan<- error,
+	path string,
+) (
+	<-chan *reddit.Post,
+	<-chan *reddit.Comment,
+	<-chan *reddit.Message,
+	<-chan *reddit.ModAction,
+	error,
+) {
+	return s.streamFromKeyedPath(scanner, kill, errs, path, path)
+}
+
+// streamFromKeyedPath is like streamFromPath, but saves the stream's position
+// under key, so it does not share a checkpoint with other streams of the path.
+func (s *Streamer) streamFromKeyedPath(
 	scanner reddit.Scanner,
 	kill <-chan bool,
 	errs chan<- error,
 	path string,
+	key string,
 ) (
 	<-chan *reddit.Post,
 	<-chan *reddit.Comment,
 	<-chan *reddit.Message,
+	<-chan *reddit.ModAction,
 	error,
 ) {
-	mon, err := monitorFromPath(path, scanner)
+	mon, err := s.monitorFromPath(s.context(kill), path, key, scanner)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
-	posts, comments, messages := stream(mon, kill, errs)
-	return posts, comments, messages, nil
+	posts, comments, messages, actions := stream(mon, kill, errs)
+	return posts, comments, messages, actions, nil
 }
 
-func monitorFromPath(path string, sc reddit.Scanner) (monitor.Monitor, error) {
+func (s *Streamer) monitorFromPath(
+	ctx context.Context,
+	path string,
+	key string,
+	sc reddit.Scanner,
+) (monitor.Monitor, error) {
 	return monitor.New(
 		monitor.Config{
-			Path:    path,
-			Scanner: sc,
-			Sorter:  rsort.New(),
+			Path:        path,
+			Key:         key,
+			Scanner:     sc,
+			Context:     ctx,
+			Sorter:      rsort.New(),
+			Checkpoints: s.Checkpoints,
+			CatchUp:     s.CatchUp,
 		},
 	)
 }
@@ -241,14 +597,29 @@ func stream(
 	<-chan *reddit.Post,
 	<-chan *reddit.Comment,
 	<-chan *reddit.Message,
+	<-chan *reddit.ModAction,
 ) {
 	posts := make(chan *reddit.Post)
 	comments := make(chan *reddit.Comment)
 	messages := make(chan *reddit.Message)
+	actions := make(chan *reddit.ModAction)
+
+	go flow(mon, kill, errs, posts, comments, messages, actions)
 
-	go flow(mon, kill, errs, posts, comments, messages)
+	return posts, comments, messages, actions
+}
+
+// report sends an error, if there is one, unless the stream is killed first;
+// killing a stream cancels its requests, and nothing reads their errors.
+func report(kill <-chan bool, errs chan<- error, err error) {
+	if err == nil {
+		return
+	}
 
-	return posts, comments, messages
+	select {
+	case errs <- err:
+	case <-kill:
+	}
 }
 
 func flow(
@@ -258,6 +629,7 @@ func flow(
 	posts chan<- *reddit.Post,
 	comments chan<- *reddit.Comment,
 	messages chan<- *reddit.Message,
+	actions chan<- *reddit.ModAction,
 ) {
 	for {
 		select {
@@ -267,22 +639,28 @@ func flow(
 			close(posts)
 			close(comments)
 			close(messages)
+			close(actions)
 			return
 		default:
-			if h, err := mon.Update(); err != nil {
-				errs <- err
-			} else {
-				// lol no generics
-				for _, p := range h.Posts {
-					posts <- p
-				}
-				for _, c := range h.Comments {
-					comments <- c
-				}
-				for _, m := range h.Messages {
-					messages <- m
-				}
+			// A failed update may still have advanced the
+			// monitor (e.g. if its checkpoint could not be
+			// saved), so deliver what it returned before
+			// reporting the error.
+			h, err := mon.Update()
+			// lol no generics
+			for _, p := range h.Posts {
+				posts <- p
+			}
+			for _, c := range h.Comments {
+				comments <- c
+			}
+			for _, m := range h.Messages {
+				messages <- m
+			}
+			for _, a := range h.ModActions {
+				actions <- a
 			}
+			report(kill, errs, err)
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package streams
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
@@ -30,14 +31,17 @@ func TestStream(t *testing.T) {
 			Messages: []*reddit.Message{
 				&reddit.Message{Body: "body"},
 			},
+			ModActions: []*reddit.ModAction{
+				&reddit.ModAction{Action: "removelink"},
+			},
 		},
 	}
 
-	posts, comments, messages := stream(mon, kill, errs)
+	posts, comments, messages, actions := stream(mon, kill, errs)
 
 	done := make(chan bool)
 	wg := &sync.WaitGroup{}
-	wg.Add(3)
+	wg.Add(4)
 	go func() {
 		<-posts
 		wg.Done()
@@ -50,6 +54,10 @@ func TestStream(t *testing.T) {
 		<-messages
 		wg.Done()
 	}()
+	go func() {
+		<-actions
+		wg.Done()
+	}()
 	go func() {
 		wg.Wait()
 		done <- true
@@ -70,9 +78,10 @@ func TestErrorPropagation(t *testing.T) {
 	posts := make(chan *reddit.Post)
 	comments := make(chan *reddit.Comment)
 	messages := make(chan *reddit.Message)
+	actions := make(chan *reddit.ModAction)
 	mon := &mockMonitor{err: fmt.Errorf("an error")}
 	go func() {
-		flow(mon, kill, errs, posts, comments, messages)
+		flow(mon, kill, errs, posts, comments, messages, actions)
 		done <- true
 	}()
 	go func() {
@@ -92,3 +101,87 @@ func TestErrorPropagation(t *testing.T) {
 		t.Errorf("loop did not report error or accept kill")
 	}
 }
+
+func TestStreamerContext(t *testing.T) {
+	kill := make(chan bool)
+	ctx := (&Streamer{}).context(kill)
+	if ctx.Err() != nil {
+		t.Fatalf("context done before the stream was killed: %v", ctx.Err())
+	}
+
+	close(kill)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Errorf("context not cancelled when the stream was killed")
+	}
+}
+
+// pathBot records the listings a stream requests, and serves nothing from
+// them.
+type pathBot struct {
+	reddit.Bot
+
+	mu    sync.Mutex
+	paths []string
+}
+
+func (b *pathBot) ListingContext(
+	_ context.Context,
+	path, _ string,
+) (reddit.Harvest, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.paths = append(b.paths, path)
+	return reddit.Harvest{}, nil
+}
+
+func TestModStreamPaths(t *testing.T) {
+	for i, test := range []struct {
+		open func(reddit.Bot, <-chan bool, chan<- error) error
+		path string
+	}{
+		{
+			func(b reddit.Bot, kill <-chan bool, errs chan<- error) error {
+				_, _, err := ModQueue(b, kill, errs, "a", "b")
+				return err
+			},
+			"/r/a+b/about/modqueue",
+		},
+		{
+			func(b reddit.Bot, kill <-chan bool, errs chan<- error) error {
+				_, _, err := Reports(b, kill, errs, "a", "b")
+				return err
+			},
+			"/r/a+b/about/reports",
+		},
+		{
+			func(b reddit.Bot, kill <-chan bool, errs chan<- error) error {
+				_, _, err := Spam(b, kill, errs, "a", "b")
+				return err
+			},
+			"/r/a+b/about/spam",
+		},
+		{
+			func(b reddit.Bot, kill <-chan bool, errs chan<- error) error {
+				_, err := Unmoderated(b, kill, errs, "mod")
+				return err
+			},
+			"/r/mod/about/unmoderated",
+		},
+	} {
+		bot := &pathBot{}
+		kill := make(chan bool)
+		if err := test.open(bot, kill, make(chan error)); err != nil {
+			t.Errorf("%d: failed to open stream: %v", i, err)
+			continue
+		}
+		close(kill)
+
+		bot.mu.Lock()
+		if len(bot.paths) == 0 || bot.paths[0] != test.path {
+			t.Errorf("%d: got paths %v; wanted %s", i, bot.paths, test.path)
+		}
+		bot.mu.Unlock()
+	}
+}